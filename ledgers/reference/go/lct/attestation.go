@@ -0,0 +1,212 @@
+package lct
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CoSignatureWindow is the maximum distance between an attestation's
+// timestamp and each co-signature's timestamp accepted by ValidateDocument.
+var CoSignatureWindow = 5 * time.Minute
+
+// NewAttestation returns an attestation timestamped with the current UTC
+// time. It returns an error if witness, typ or sig is empty.
+func NewAttestation(witness, typ, sig string, claims map[string]interface{}) (Attestation, error) {
+	if witness == "" || typ == "" || sig == "" {
+		return Attestation{}, fmt.Errorf("attestation requires witness, type and signature")
+	}
+	return Attestation{
+		Witness: witness,
+		Type:    typ,
+		Sig:     sig,
+		TS:      Now().UTC().Format(time.RFC3339),
+		Claims:  claims,
+	}, nil
+}
+
+// OrphanedWitnesses returns the MRH witnessing relationships whose LCTID
+// never appears as an Attestation.Witness in the document, indicating the
+// attestation record is incomplete.
+func (doc *Document) OrphanedWitnesses() []MRHWitnessing {
+	attested := make(map[string]bool, len(doc.Attestations))
+	for _, a := range doc.Attestations {
+		attested[a.Witness] = true
+	}
+	var orphans []MRHWitnessing
+	for _, w := range doc.MRH.Witnessing {
+		if !attested[w.LCTID] {
+			orphans = append(orphans, w)
+		}
+	}
+	return orphans
+}
+
+// attestationWitnessRoles maps attestation type namespaces (the part
+// before the first ':') to the witness role they imply. Bare role names,
+// as produced by WitnessBuilder, map to themselves.
+var attestationWitnessRoles = map[string]WitnessRole{
+	"time":      WitnessTime,
+	"audit":     WitnessAudit,
+	"oracle":    WitnessOracle,
+	"peer":      WitnessPeer,
+	"existence": WitnessExistence,
+	"action":    WitnessAction,
+	"task":      WitnessAction,
+	"state":     WitnessState,
+	"quality":   WitnessQuality,
+}
+
+// WitnessRoleFromAttestationType infers the witness role implied by an
+// attestation type such as "oracle:claim" or "time:attest". It returns
+// false when the type's namespace implies no role.
+func WitnessRoleFromAttestationType(typ string) (WitnessRole, bool) {
+	ns, _, _ := strings.Cut(typ, ":")
+	role, ok := attestationWitnessRoles[ns]
+	return role, ok
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Witness Builder
+// ═══════════════════════════════════════════════════════════════
+
+// WitnessBuilder assembles a signed witness attestation whose type is the
+// witness role. Claims are signed in canonical form (RFC 8785 JSON),
+// so the same claims always produce the same signed bytes.
+type WitnessBuilder struct {
+	witness string
+	role    WitnessRole
+	claims  map[string]interface{}
+	signer  func([]byte) (string, error)
+}
+
+// NewWitness starts an attestation from witness in the given role.
+func NewWitness(witness string, role WitnessRole) *WitnessBuilder {
+	return &WitnessBuilder{
+		witness: witness,
+		role:    role,
+		claims:  map[string]interface{}{},
+	}
+}
+
+// WithClaim sets a claim, replacing any previous value for key.
+func (w *WitnessBuilder) WithClaim(key string, val interface{}) *WitnessBuilder {
+	w.claims[key] = val
+	return w
+}
+
+// Sign sets the function that signs the canonical claim bytes.
+func (w *WitnessBuilder) Sign(signer func([]byte) (string, error)) *WitnessBuilder {
+	w.signer = signer
+	return w
+}
+
+// CanonicalClaims returns the bytes Build passes to the signer: the claims
+// serialized per RFC 8785, as for Document.CanonicalBytes.
+func (w *WitnessBuilder) CanonicalClaims() ([]byte, error) {
+	data, err := json.Marshal(w.claims)
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSONTree(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Build signs the canonical claims and returns the timestamped attestation.
+func (w *WitnessBuilder) Build() (Attestation, error) {
+	if w.signer == nil {
+		return Attestation{}, fmt.Errorf("witness attestation requires a signer")
+	}
+	payload, err := w.CanonicalClaims()
+	if err != nil {
+		return Attestation{}, fmt.Errorf("encoding claims: %w", err)
+	}
+	sig, err := w.signer(payload)
+	if err != nil {
+		return Attestation{}, fmt.Errorf("signing claims: %w", err)
+	}
+	claims := make(map[string]interface{}, len(w.claims))
+	for k, v := range w.claims {
+		claims[k] = v
+	}
+	return NewAttestation(w.witness, string(w.role), sig, claims)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Co-Signatures
+// ═══════════════════════════════════════════════════════════════
+
+// WitnessCount returns the number of distinct witnesses that signed the
+// attestation: the primary witness plus each distinct co-signer.
+func (a *Attestation) WitnessCount() int {
+	seen := map[string]bool{}
+	if a.Witness != "" {
+		seen[a.Witness] = true
+	}
+	for _, cs := range a.CoSignatures {
+		if cs.Witness != "" {
+			seen[cs.Witness] = true
+		}
+	}
+	return len(seen)
+}
+
+// ValidateCoSignatures checks that every co-signature is complete and
+// timestamped within window of the primary attestation.
+func (a *Attestation) ValidateCoSignatures(window time.Duration) error {
+	if len(a.CoSignatures) == 0 {
+		return nil
+	}
+	primary, err := time.Parse(time.RFC3339, a.TS)
+	if err != nil {
+		return fmt.Errorf("co-signed attestation has invalid ts %q", a.TS)
+	}
+	for i, cs := range a.CoSignatures {
+		if cs.Witness == "" || cs.Sig == "" {
+			return fmt.Errorf("co_signatures[%d] missing witness or sig", i)
+		}
+		ts, err := time.Parse(time.RFC3339, cs.TS)
+		if err != nil {
+			return fmt.Errorf("co_signatures[%d] has invalid ts %q", i, cs.TS)
+		}
+		delta := ts.Sub(primary)
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > window {
+			return fmt.Errorf("co_signatures[%d] by %s is %s from primary (window %s)", i, cs.Witness, delta, window)
+		}
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Replay Protection
+// ═══════════════════════════════════════════════════════════════
+
+// HasDuplicateAttestationNonces returns each nonce used by more than one
+// attestation, in order of first use. Attestations without a nonce are
+// ignored.
+func (doc *Document) HasDuplicateAttestationNonces() []string {
+	var dups []string
+	counts := map[string]int{}
+	for _, a := range doc.Attestations {
+		if a.Nonce == "" {
+			continue
+		}
+		counts[a.Nonce]++
+		if counts[a.Nonce] == 2 {
+			dups = append(dups, a.Nonce)
+		}
+	}
+	return dups
+}
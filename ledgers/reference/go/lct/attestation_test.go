@@ -0,0 +1,309 @@
+package lct
+
+import (
+	"testing"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Co-Signature Tests
+// ═══════════════════════════════════════════════════════════════
+
+func coSignedAttestation(coTS string) Attestation {
+	return Attestation{
+		Witness: "lct:web4:witness:w1",
+		Type:    "action:approve",
+		Sig:     "cose:sig1",
+		TS:      "2026-02-19T00:00:00Z",
+		CoSignatures: []CoSig{{
+			Witness: "lct:web4:witness:w2",
+			Sig:     "cose:sig2",
+			TS:      coTS,
+		}},
+	}
+}
+
+func TestCoSignedAttestation(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Attestations = []Attestation{coSignedAttestation("2026-02-19T00:01:30Z")}
+
+	if n := doc.Attestations[0].WitnessCount(); n != 2 {
+		t.Errorf("Expected 2 witnesses, got %d", n)
+	}
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Errorf("Expected valid co-signed attestation, got: %v", result.Errors)
+	}
+}
+
+func TestStaleCoSignature(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Attestations = []Attestation{coSignedAttestation("2026-02-19T01:00:00Z")}
+
+	result := ValidateDocument(doc)
+	if result.Valid {
+		t.Fatal("Expected stale co-signature to be rejected")
+	}
+	if !contains(result.Errors[0], "co_signatures[0]") {
+		t.Errorf("Expected co-signature error, got: %v", result.Errors)
+	}
+
+	a := doc.Attestations[0]
+	if err := a.ValidateCoSignatures(2 * time.Hour); err != nil {
+		t.Errorf("Expected co-signature within a 2h window, got: %v", err)
+	}
+}
+
+func TestWitnessCountDeduplicates(t *testing.T) {
+	a := coSignedAttestation("2026-02-19T00:00:00Z")
+	a.CoSignatures = append(a.CoSignatures, CoSig{Witness: "lct:web4:witness:w1", Sig: "cose:dup", TS: a.TS})
+	if n := a.WitnessCount(); n != 2 {
+		t.Errorf("Expected duplicate co-signer to count once, got %d", n)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Replay Protection Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestAttestationNoncesUnique(t *testing.T) {
+	doc := NewBuilder(EntityAI, "nonce").
+		AddAttestationWithNonce("lct:web4:witness:w1", "existence", "cose:a", "n-1", nil).
+		AddAttestationWithNonce("lct:web4:witness:w2", "existence", "cose:b", "n-2", nil).
+		BuildUnsafe()
+
+	if dups := doc.HasDuplicateAttestationNonces(); len(dups) != 0 {
+		t.Errorf("Expected no duplicate nonces, got %v", dups)
+	}
+	if doc.Attestations[0].Nonce != "n-1" || doc.Attestations[0].TS == "" {
+		t.Errorf("Unexpected attestation: %+v", doc.Attestations[0])
+	}
+	for _, w := range ValidateDocument(doc).Warnings {
+		if contains(w, "nonce") {
+			t.Errorf("Unexpected nonce warning: %s", w)
+		}
+	}
+}
+
+func TestAttestationNonceDuplicated(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Attestations = []Attestation{
+		{Witness: "lct:web4:witness:w1", Type: "existence", Sig: "cose:a", TS: "2026-02-19T00:00:00Z", Nonce: "n-1"},
+		{Witness: "lct:web4:witness:w2", Type: "existence", Sig: "cose:b", TS: "2026-02-19T00:00:00Z", Nonce: "n-1"},
+		{Witness: "lct:web4:witness:w3", Type: "existence", Sig: "cose:c", TS: "2026-02-19T00:00:00Z", Nonce: "n-1"},
+		{Witness: "lct:web4:witness:w3", Type: "existence", Sig: "cose:d", TS: "2026-02-19T00:00:00Z"},
+	}
+
+	dups := doc.HasDuplicateAttestationNonces()
+	if len(dups) != 1 || dups[0] != "n-1" {
+		t.Errorf("Expected [n-1], got %v", dups)
+	}
+
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Duplicate nonces should warn, not fail: %v", result.Errors)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		if contains(w, "Duplicate attestation nonce") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected duplicate nonce warning, got %v", result.Warnings)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Orphaned Witness Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestOrphanedWitnessesMatched(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:time", Role: WitnessTime, LastAttestation: "2026-02-19T00:00:00Z"}}
+	doc.Attestations = []Attestation{{Witness: "lct:web4:oracle:time", Type: "time", Sig: "cose:t", TS: "2026-02-19T00:00:00Z"}}
+
+	if orphans := doc.OrphanedWitnesses(); len(orphans) != 0 {
+		t.Errorf("Expected no orphans, got %+v", orphans)
+	}
+	for _, w := range ValidateDocument(doc).Warnings {
+		if contains(w, "no matching attestation") {
+			t.Errorf("Unexpected orphan warning: %s", w)
+		}
+	}
+}
+
+func TestOrphanedWitnessesWarning(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:time", Role: WitnessTime, LastAttestation: "2026-02-19T00:00:00Z"}}
+
+	orphans := doc.OrphanedWitnesses()
+	if len(orphans) != 1 || orphans[0].LCTID != "lct:web4:oracle:time" {
+		t.Fatalf("Expected one orphan, got %+v", orphans)
+	}
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Orphans should only warn, got errors: %v", result.Errors)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		if contains(w, `"lct:web4:oracle:time" has no matching attestation`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected orphan warning, got: %v", result.Warnings)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Witness Role Inference Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestWitnessRoleFromAttestationType(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want WitnessRole
+	}{
+		{AttestationOracleClaim, WitnessOracle},
+		{"time:attest", WitnessTime},
+		{AttestationTaskCompleted, WitnessAction},
+		{"existence", WitnessExistence},
+		{"audit:quarterly", WitnessAudit},
+	}
+	for _, tt := range tests {
+		got, ok := WitnessRoleFromAttestationType(tt.typ)
+		if !ok || got != tt.want {
+			t.Errorf("WitnessRoleFromAttestationType(%q) = %q, %v; want %q", tt.typ, got, ok, tt.want)
+		}
+	}
+	for _, typ := range []string{AttestationBindingRotation, "telepathy:ping", ""} {
+		if role, ok := WitnessRoleFromAttestationType(typ); ok {
+			t.Errorf("Expected no inference for %q, got %q", typ, role)
+		}
+	}
+}
+
+func TestBuilderAddAttestationLinkingWitness(t *testing.T) {
+	b := NewBuilder(EntityAI, "linked").
+		AddAttestationLinkingWitness("lct:web4:oracle:time", "time:attest", "cose:t1", nil).
+		AddAttestationLinkingWitness("lct:web4:oracle:price", AttestationOracleClaim, "cose:p1", nil).
+		AddAttestationLinkingWitness("lct:web4:oracle:time", "time:attest", "cose:t2", nil)
+	doc := b.BuildUnsafe()
+
+	if len(doc.Attestations) != 3 {
+		t.Errorf("Expected 3 attestations, got %d", len(doc.Attestations))
+	}
+	if len(doc.MRH.Witnessing) != 2 {
+		t.Fatalf("Expected one witnessing entry per witness, got %+v", doc.MRH.Witnessing)
+	}
+	if doc.MRH.Witnessing[0].Role != WitnessTime || doc.MRH.Witnessing[1].Role != WitnessOracle {
+		t.Errorf("Expected inferred time and oracle roles, got %+v", doc.MRH.Witnessing)
+	}
+	if orphans := doc.OrphanedWitnesses(); len(orphans) != 0 {
+		t.Errorf("Linked witnesses should not be orphaned, got %+v", orphans)
+	}
+
+	_, err := NewBuilder(EntityAI, "unlinked").
+		AddAttestationLinkingWitness("lct:web4:oracle:x", "telepathy:ping", "cose:x", nil).
+		Build()
+	if err == nil || !contains(err.Error(), "cannot infer witness role") {
+		t.Errorf("Expected inference error for unknown type, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Constructor Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestNewAttestation(t *testing.T) {
+	before := time.Now().UTC().Add(-time.Second)
+	att, err := NewAttestation("lct:web4:witness:w1", "existence", "cose:sig", map[string]interface{}{"k": "v"})
+	if err != nil {
+		t.Fatalf("NewAttestation failed: %v", err)
+	}
+	ts, err := time.Parse(time.RFC3339, att.TS)
+	if err != nil {
+		t.Fatalf("Expected RFC3339 timestamp, got %q", att.TS)
+	}
+	if ts.Before(before) || ts.After(time.Now().UTC()) {
+		t.Errorf("Timestamp %s not current", att.TS)
+	}
+	assertEqual(t, "witness", "lct:web4:witness:w1", att.Witness)
+	assertEqual(t, "type", "existence", att.Type)
+	assertEqual(t, "sig", "cose:sig", att.Sig)
+}
+
+func TestNewAttestationEmptyFields(t *testing.T) {
+	for _, args := range [][3]string{
+		{"", "existence", "cose:sig"},
+		{"lct:web4:witness:w1", "", "cose:sig"},
+		{"lct:web4:witness:w1", "existence", ""},
+	} {
+		if _, err := NewAttestation(args[0], args[1], args[2], nil); err == nil {
+			t.Errorf("Expected error for %q", args)
+		}
+	}
+
+	_, err := NewBuilder(EntityAI, "test-agent").
+		WithBinding("mb64testkey", "cose:test_proof").
+		AddAttestationWithNonce("lct:web4:witness:w1", "existence", "", "n-1", nil).
+		Build()
+	if err == nil {
+		t.Error("Expected build error for unsigned attestation")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Witness Builder Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestWitnessBuilderTimeAttestation(t *testing.T) {
+	var signed [][]byte
+	signer := func(b []byte) (string, error) {
+		signed = append(signed, b)
+		return "cose:time_sig", nil
+	}
+
+	build := func() Attestation {
+		att, err := NewWitness("lct:web4:oracle:time", WitnessTime).
+			WithClaim("ts", "2026-02-19T00:00:00Z").
+			WithClaim("source", "ntp").
+			WithClaim("drift_ms", 3).
+			Sign(signer).
+			Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		return att
+	}
+	att := build()
+	build()
+
+	assertEqual(t, "type", "time", att.Type)
+	assertEqual(t, "witness", "lct:web4:oracle:time", att.Witness)
+	assertEqual(t, "sig", "cose:time_sig", att.Sig)
+	if att.TS == "" || att.Claims["source"] != "ntp" {
+		t.Errorf("Unexpected attestation: %+v", att)
+	}
+	assertEqual(t, "canonical bytes", `{"drift_ms":3,"source":"ntp","ts":"2026-02-19T00:00:00Z"}`, string(signed[0]))
+	assertEqual(t, "stable bytes", string(signed[0]), string(signed[1]))
+}
+
+func TestWitnessBuilderCanonicalClaimsRFC8785(t *testing.T) {
+	payload, err := NewWitness("lct:web4:oracle:claims", WitnessOracle).
+		WithClaim("query", "a<b && c>d").
+		WithClaim("name", "Zoë").
+		WithClaim("score", 0.5).
+		CanonicalClaims()
+	if err != nil {
+		t.Fatalf("CanonicalClaims failed: %v", err)
+	}
+	assertEqual(t, "canonical bytes", `{"name":"Zoë","query":"a<b && c>d","score":0.5}`, string(payload))
+}
+
+func TestWitnessBuilderRequiresSigner(t *testing.T) {
+	if _, err := NewWitness("lct:web4:oracle:time", WitnessTime).Build(); err == nil {
+		t.Error("Expected error without a signer")
+	}
+}
@@ -19,18 +19,26 @@ import (
 type Builder struct {
 	doc        Document
 	entityType EntityType
+	namespace  string
+	hash       string
+	err        error
+	warnings   []string
 }
 
 // NewBuilder creates a new LCT document builder.
 func NewBuilder(entityType EntityType, name string) *Builder {
-	hash := simpleHash(fmt.Sprintf("%s:%s:%d", entityType, name, time.Now().UnixNano()))
-	now := time.Now().UTC().Format(time.RFC3339)
+	hash := simpleHash(fmt.Sprintf("%s:%s:%d", entityType, name, Now().UnixNano()))
+	now := Now().UTC().Format(time.RFC3339)
+	namespace := Namespace()
 
 	return &Builder{
 		entityType: entityType,
+		namespace:  namespace,
+		hash:       hash,
 		doc: Document{
-			LCTID:   fmt.Sprintf("lct:web4:%s:%s", entityType, hash),
-			Subject: fmt.Sprintf("did:web4:key:%s", hash),
+			SchemaVersion: CurrentSchemaVersion,
+			LCTID:         fmt.Sprintf("lct:%s:%s:%s", namespace, entityType, hash),
+			Subject:       fmt.Sprintf("did:web4:key:%s", hash),
 			Binding: Binding{
 				EntityType: entityType,
 				CreatedAt:  now,
@@ -50,6 +58,19 @@ func NewBuilder(entityType EntityType, name string) *Builder {
 	}
 }
 
+// WithNamespace issues the LCT ID under namespace ns instead of the
+// package default (e.g. "acme" for "lct:acme:..."). An invalid namespace
+// fails the build.
+func (b *Builder) WithNamespace(ns string) *Builder {
+	if err := ValidateNamespace(ns); err != nil {
+		b.err = err
+		return b
+	}
+	b.namespace = ns
+	b.doc.LCTID = fmt.Sprintf("lct:%s:%s:%s", ns, b.entityType, b.hash)
+	return b
+}
+
 // WithBinding sets the public key and binding proof.
 func (b *Builder) WithBinding(publicKey, bindingProof string) *Builder {
 	b.doc.Binding.PublicKey = publicKey
@@ -57,6 +78,34 @@ func (b *Builder) WithBinding(publicKey, bindingProof string) *Builder {
 	return b
 }
 
+// AddBindingProof adds a co-signature over the binding from an additional
+// authority. An empty proof fails the build.
+func (b *Builder) AddBindingProof(proof string) *Builder {
+	if proof == "" {
+		if b.err == nil {
+			b.err = fmt.Errorf("additional binding proof must not be empty")
+		}
+		return b
+	}
+	b.doc.Binding.AdditionalProofs = append(b.doc.Binding.AdditionalProofs, proof)
+	return b
+}
+
+// WithSubjectFromKey derives the subject DID from the binding's public key
+// (see SubjectFromPublicKey). Call it after WithBinding; an invalid key
+// fails the build.
+func (b *Builder) WithSubjectFromKey() *Builder {
+	subject, err := SubjectFromPublicKey(b.doc.Binding.PublicKey)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("deriving subject: %w", err)
+		}
+		return b
+	}
+	b.doc.Subject = subject
+	return b
+}
+
 // WithHardwareAnchor sets the EAT hardware attestation token.
 func (b *Builder) WithHardwareAnchor(anchor string) *Builder {
 	b.doc.Binding.HardwareAnchor = anchor
@@ -69,7 +118,7 @@ func (b *Builder) WithBirthCertificate(
 	context BirthContext,
 	witnesses []string,
 ) *Builder {
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := Now().UTC().Format(time.RFC3339)
 	b.doc.BirthCert = BirthCertificate{
 		IssuingSociety: issuingSociety,
 		CitizenRole:    citizenRole,
@@ -87,26 +136,30 @@ func (b *Builder) WithBirthCertificate(
 	return b
 }
 
-// WithT3 sets the trust tensor with the 3 canonical root dimensions.
+// WithT3 sets the trust tensor with the 3 canonical root dimensions and
+// records the default composite weights it was scored with.
 func (b *Builder) WithT3(talent, training, temperament float64) *Builder {
 	t3 := &T3Tensor{
 		Talent:      talent,
 		Training:    training,
 		Temperament: temperament,
-		LastComputed: time.Now().UTC().Format(time.RFC3339),
+		Weights:     DefaultT3Weights(),
+		LastComputed: Now().UTC().Format(time.RFC3339),
 	}
 	t3.CompositeScore = ComputeT3Composite(t3)
 	b.doc.T3 = t3
 	return b
 }
 
-// WithV3 sets the value tensor with the 3 canonical root dimensions.
+// WithV3 sets the value tensor with the 3 canonical root dimensions and
+// records the default composite weights it was scored with.
 func (b *Builder) WithV3(valuation, veracity, validity float64) *Builder {
 	v3 := &V3Tensor{
 		Valuation: valuation,
 		Veracity:  veracity,
 		Validity:  validity,
-		LastComputed: time.Now().UTC().Format(time.RFC3339),
+		Weights:   DefaultV3Weights(),
+		LastComputed: Now().UTC().Format(time.RFC3339),
 	}
 	v3.CompositeScore = ComputeV3Composite(v3)
 	b.doc.V3 = v3
@@ -114,7 +167,13 @@ func (b *Builder) WithV3(valuation, veracity, validity float64) *Builder {
 }
 
 // AddCapability adds a capability string to the policy.
+// Capabilities already present are ignored.
 func (b *Builder) AddCapability(capability string) *Builder {
+	for _, c := range b.doc.Policy.Capabilities {
+		if c == capability {
+			return b
+		}
+	}
 	b.doc.Policy.Capabilities = append(b.doc.Policy.Capabilities, capability)
 	return b
 }
@@ -130,8 +189,16 @@ func (b *Builder) AddBound(lctID string, boundType BoundType) *Builder {
 	b.doc.MRH.Bound = append(b.doc.MRH.Bound, MRHBound{
 		LCTID: lctID,
 		Type:  boundType,
-		TS:    time.Now().UTC().Format(time.RFC3339),
+		TS:    Now().UTC().Format(time.RFC3339),
 	})
+	b.checkHorizon()
+	return b
+}
+
+// WithHorizonDepth sets how many relationship hops the MRH spans (1-10).
+func (b *Builder) WithHorizonDepth(depth int) *Builder {
+	b.doc.MRH.HorizonDepth = depth
+	b.checkHorizon()
 	return b
 }
 
@@ -141,7 +208,7 @@ func (b *Builder) AddPairing(lctID string, pairingType PairingType, permanent bo
 		LCTID:       lctID,
 		PairingType: pairingType,
 		Permanent:   permanent,
-		TS:          time.Now().UTC().Format(time.RFC3339),
+		TS:          Now().UTC().Format(time.RFC3339),
 	})
 	return b
 }
@@ -151,7 +218,57 @@ func (b *Builder) AddWitness(lctID string, role WitnessRole) *Builder {
 	b.doc.MRH.Witnessing = append(b.doc.MRH.Witnessing, MRHWitnessing{
 		LCTID:           lctID,
 		Role:            role,
-		LastAttestation: time.Now().UTC().Format(time.RFC3339),
+		LastAttestation: Now().UTC().Format(time.RFC3339),
+	})
+	return b
+}
+
+// AddAttestationWithNonce adds a witness attestation bound to a
+// single-use nonce.
+func (b *Builder) AddAttestationWithNonce(witness, attType, sig, nonce string, claims map[string]interface{}) *Builder {
+	att, err := NewAttestation(witness, attType, sig, claims)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	att.Nonce = nonce
+	b.doc.Attestations = append(b.doc.Attestations, att)
+	return b
+}
+
+// AddAttestationLinkingWitness adds a witness attestation and records the
+// witness in the MRH with the role inferred from attType (see
+// WitnessRoleFromAttestationType). An existing witnessing entry for the
+// witness has its last attestation time refreshed instead. A type that
+// implies no role fails the build.
+func (b *Builder) AddAttestationLinkingWitness(witness, attType, sig string, claims map[string]interface{}) *Builder {
+	role, ok := WitnessRoleFromAttestationType(attType)
+	if !ok {
+		if b.err == nil {
+			b.err = fmt.Errorf("cannot infer witness role from attestation type %q", attType)
+		}
+		return b
+	}
+	att, err := NewAttestation(witness, attType, sig, claims)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	b.doc.Attestations = append(b.doc.Attestations, att)
+	for i := range b.doc.MRH.Witnessing {
+		if b.doc.MRH.Witnessing[i].LCTID == witness {
+			b.doc.MRH.Witnessing[i].LastAttestation = att.TS
+			return b
+		}
+	}
+	b.doc.MRH.Witnessing = append(b.doc.MRH.Witnessing, MRHWitnessing{
+		LCTID:           witness,
+		Role:            role,
+		LastAttestation: att.TS,
 	})
 	return b
 }
@@ -161,17 +278,83 @@ func (b *Builder) AddLineage(reason LineageReason, parent string) *Builder {
 	b.doc.Lineage = append(b.doc.Lineage, LineageEntry{
 		Parent: parent,
 		Reason: reason,
-		TS:     time.Now().UTC().Format(time.RFC3339),
+		TS:     Now().UTC().Format(time.RFC3339),
 	})
 	return b
 }
 
+// Snapshot returns an independent copy of the builder. Fluent calls on the
+// snapshot do not affect the original, so a shared base can branch into
+// multiple variants.
+func (b *Builder) Snapshot() *Builder {
+	return &Builder{
+		doc:        *b.doc.Clone(),
+		entityType: b.entityType,
+		namespace:  b.namespace,
+		hash:       b.hash,
+		err:        b.err,
+		warnings:   cloneStrings(b.warnings),
+	}
+}
+
+// Warnings returns the advisory issues collected by fluent calls. They do
+// not fail Build.
+func (b *Builder) Warnings() []string {
+	return cloneStrings(b.warnings)
+}
+
+// warn records a warning once.
+func (b *Builder) warn(msg string) {
+	if !containsString(b.warnings, msg) {
+		b.warnings = append(b.warnings, msg)
+	}
+}
+
+// horizonWarning is reported while a horizon depth of 1 cannot reach the
+// declared parent bounds.
+const horizonWarning = "mrh.horizon_depth is 1 but multiple parent bounds are declared; the parent hierarchy lies beyond the horizon"
+
+// checkHorizon recomputes the horizon warning for the current depth and
+// parent bounds, dropping it once a later call resolves the problem.
+func (b *Builder) checkHorizon() {
+	kept := b.warnings[:0]
+	for _, w := range b.warnings {
+		if w != horizonWarning {
+			kept = append(kept, w)
+		}
+	}
+	b.warnings = kept
+
+	parents := 0
+	for _, bound := range b.doc.MRH.Bound {
+		if bound.Type == BoundParent {
+			parents++
+		}
+	}
+	if b.doc.MRH.HorizonDepth == 1 && parents > 1 {
+		b.warn(horizonWarning)
+	}
+}
+
+// BuildError is returned by Build when the document fails validation.
+// Use errors.As to inspect the individual failures in Result.
+type BuildError struct {
+	Result DocValidationResult
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("invalid LCT document: %v", e.Result.Errors)
+}
+
 // Build validates and returns the LCT document.
-// Returns error if validation fails.
+// Returns a *BuildError if validation fails.
 func (b *Builder) Build() (*Document, error) {
-	result := ValidateDocument(&b.doc)
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := ValidateDocumentWithOptions(&b.doc, ValidateOptions{Namespace: b.namespace})
 	if !result.Valid {
-		return nil, fmt.Errorf("invalid LCT document: %v", result.Errors)
+		return nil, &BuildError{Result: result}
 	}
 	doc := b.doc // copy
 	return &doc, nil
@@ -1,7 +1,9 @@
 package lct
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestBuilderMinimal(t *testing.T) {
@@ -141,6 +143,32 @@ func TestBuilderValidationFailure(t *testing.T) {
 	}
 }
 
+func TestBuilderBuildErrorCarriesResult(t *testing.T) {
+	_, err := NewBuilder(EntityAI, "invalid").
+		WithBinding("mb64key", "").
+		Build()
+
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) {
+		t.Fatalf("Expected *BuildError, got %T: %v", err, err)
+	}
+	if buildErr.Result.Valid {
+		t.Error("Expected an invalid result")
+	}
+	found := false
+	for _, e := range buildErr.Result.Errors {
+		if e == "Missing binding.binding_proof" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected missing binding_proof error, got %v", buildErr.Result.Errors)
+	}
+	if !contains(err.Error(), "invalid LCT document") {
+		t.Errorf("Expected readable message, got %q", err.Error())
+	}
+}
+
 func TestBuilderUnsafeBypassesValidation(t *testing.T) {
 	doc := NewBuilder(EntityAI, "partial").BuildUnsafe()
 	if doc == nil {
@@ -196,6 +224,150 @@ func TestBuilderProducesValidDocument(t *testing.T) {
 	}
 }
 
+func TestBuilderDeduplicatesCapabilities(t *testing.T) {
+	doc := NewBuilder(EntityAI, "dedup").
+		AddCapability("witness:attest").
+		AddCapability("write:lct").
+		AddCapability("witness:attest").
+		BuildUnsafe()
+
+	if len(doc.Policy.Capabilities) != 2 {
+		t.Errorf("Expected 2 capabilities after dedup, got %v", doc.Policy.Capabilities)
+	}
+}
+
+func TestBuilderSnapshotIndependence(t *testing.T) {
+	base := NewBuilder(EntityAI, "branching").
+		WithBinding("mb64key", "cose:proof").
+		WithBirthCertificate(
+			"lct:web4:society:main",
+			"lct:web4:role:citizen:ai",
+			BirthPlatform,
+			[]string{"lct:web4:witness:w1", "lct:web4:witness:w2", "lct:web4:witness:w3"},
+		)
+
+	branch := base.Snapshot()
+	base.AddCapability("read:lct")
+	branch.AddCapability("write:lct").AddCapability("witness:attest")
+	branch.AddPairing("lct:web4:service:telemetry", PairingOperational, false)
+
+	baseDoc, err := base.Build()
+	if err != nil {
+		t.Fatalf("Base build failed: %v", err)
+	}
+	branchDoc, err := branch.Build()
+	if err != nil {
+		t.Fatalf("Branch build failed: %v", err)
+	}
+
+	if len(baseDoc.Policy.Capabilities) != 1 || baseDoc.Policy.Capabilities[0] != "read:lct" {
+		t.Errorf("Base capabilities leaked from branch: %v", baseDoc.Policy.Capabilities)
+	}
+	if len(branchDoc.Policy.Capabilities) != 2 {
+		t.Errorf("Branch capabilities leaked from base: %v", branchDoc.Policy.Capabilities)
+	}
+	if len(baseDoc.MRH.Paired) != 1 || len(branchDoc.MRH.Paired) != 2 {
+		t.Errorf("Expected 1 base and 2 branch pairings, got %d and %d", len(baseDoc.MRH.Paired), len(branchDoc.MRH.Paired))
+	}
+	if baseDoc.LCTID != branchDoc.LCTID {
+		t.Error("Snapshot should share the base LCT ID")
+	}
+}
+
+func TestBuilderHorizonWarnings(t *testing.T) {
+	b := NewBuilder(EntityAI, "horizon").
+		WithBinding("mb64key", "cose:proof").
+		WithBirthCertificate(
+			"lct:web4:society:main",
+			"lct:web4:role:citizen:ai",
+			BirthPlatform,
+			[]string{"lct:web4:witness:w1", "lct:web4:witness:w2", "lct:web4:witness:w3"},
+		).
+		AddBound("lct:web4:organization:a", BoundParent)
+	if len(b.Warnings()) != 0 {
+		t.Fatalf("Expected no warnings yet, got %v", b.Warnings())
+	}
+
+	b.WithHorizonDepth(1).
+		AddBound("lct:web4:organization:b", BoundParent).
+		AddBound("lct:web4:organization:c", BoundParent)
+	warnings := b.Warnings()
+	if len(warnings) != 1 || !contains(warnings[0], "horizon_depth is 1") {
+		t.Errorf("Expected one horizon warning, got %v", warnings)
+	}
+
+	doc, err := b.Build()
+	if err != nil {
+		t.Fatalf("Warnings should not fail the build: %v", err)
+	}
+	if doc.MRH.HorizonDepth != 1 {
+		t.Errorf("Expected horizon depth 1, got %d", doc.MRH.HorizonDepth)
+	}
+	if len(b.Snapshot().Warnings()) != 1 {
+		t.Error("Snapshot should carry warnings")
+	}
+}
+
+func TestBuilderHorizonWarningClearedByCorrection(t *testing.T) {
+	b := NewBuilder(EntityAI, "horizon").
+		AddBound("lct:web4:organization:a", BoundParent).
+		AddBound("lct:web4:organization:b", BoundParent).
+		WithHorizonDepth(1)
+	if len(b.Warnings()) != 1 {
+		t.Fatalf("Expected horizon warning, got %v", b.Warnings())
+	}
+	b.WithHorizonDepth(3)
+	if len(b.Warnings()) != 0 {
+		t.Errorf("Expected corrected depth to clear the warning, got %v", b.Warnings())
+	}
+}
+
+func TestBuilderFrozenClock(t *testing.T) {
+	frozen := time.Date(2026, 2, 19, 12, 0, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { Now = orig }(Now)
+	Now = func() time.Time { return frozen }
+
+	build := func() *Document {
+		doc, err := NewBuilder(EntityAI, "frozen").
+			WithBinding("mb64key", "cose:proof").
+			WithBirthCertificate(
+				"lct:web4:society:main",
+				"lct:web4:role:citizen:ai",
+				BirthPlatform,
+				[]string{"lct:web4:witness:w1", "lct:web4:witness:w2", "lct:web4:witness:w3"},
+			).
+			WithT3(0.7, 0.8, 0.6).
+			WithV3(0.3, 0.9, 0.7).
+			AddBound("lct:web4:society:main", BoundParent).
+			AddPairing("lct:web4:service:telemetry", PairingOperational, false).
+			AddWitness("lct:web4:witness:w1", WitnessExistence).
+			AddAttestationWithNonce("lct:web4:witness:w1", "existence", "cose:sig", "n-1", nil).
+			AddLineage(LineageGenesis, "").
+			Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		return doc
+	}
+	doc := build()
+
+	want := frozen.Format(time.RFC3339)
+	count := 0
+	doc.mapTimestamps(func(ts string) string {
+		if ts != "" {
+			count++
+			assertEqual(t, "timestamp", want, ts)
+		}
+		return ts
+	})
+	if count < 10 {
+		t.Errorf("Expected every builder timestamp to be set, saw %d", count)
+	}
+	if again := build(); again.Hash() != doc.Hash() {
+		t.Error("Expected identical output under a frozen clock")
+	}
+}
+
 func abs(x float64) float64 {
 	if x < 0 {
 		return -x
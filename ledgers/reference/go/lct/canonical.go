@@ -0,0 +1,214 @@
+package lct
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// CanonicalBytes returns the document's canonical encoding, a byte-exact
+// form shared with other-language implementations for test vectors:
+//
+//  1. MRH bound, paired, and witnessing entries are sorted by lct_id
+//     (see Canonicalize).
+//  2. Every RFC 3339 timestamp is converted to UTC and written with a "Z"
+//     suffix and only the fractional-second digits needed (trailing zeros
+//     removed). Unparseable timestamps are kept verbatim.
+//  3. Fields are those of the JSON schema; optional fields that are empty
+//     are omitted, and unknown fields preserved in Extra are included.
+//  4. The result is serialized per RFC 8785 (JSON Canonicalization Scheme):
+//     no insignificant whitespace; object keys sorted by UTF-16 code
+//     units; numbers in ECMAScript shortest round-trip form (1, 0.35,
+//     1e+21, 1e-7); strings escape only '"', '\\', and control characters,
+//     using \b \f \n \r \t where defined and lowercase \u00xx otherwise,
+//     with all other characters emitted as UTF-8.
+//
+// NaN and infinite tensor values cannot be encoded and return an error.
+func (doc *Document) CanonicalBytes() ([]byte, error) {
+	c := doc.Clone()
+	c.Canonicalize()
+	c.mapTimestamps(canonicalTimestamp)
+	return c.MarshalJSON()
+}
+
+// canonicalTimestamp normalizes an RFC 3339 timestamp to UTC.
+func canonicalTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		n, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("canonical json: unsupported value of type %T", v)
+	}
+	return nil
+}
+
+// canonicalNumber re-emits n in ECMAScript shortest round-trip form, so
+// equal values such as 1, 1.0 and 1E0 always encode identically: plain
+// decimal notation for magnitudes in [1e-6, 1e21), otherwise exponent
+// notation with an explicit sign and no leading zeros ("1e+21", "1e-7").
+// Negative zero is written as 0.
+func canonicalNumber(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", fmt.Errorf("canonical json: invalid number %q: %w", n, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e21 || abs < 1e-6 {
+		mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+		return mantissa + "e" + exp[:1] + strings.TrimLeft(exp[1:], "0"), nil
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// lessUTF16 orders strings by their UTF-16 code units, as RFC 8785 requires.
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Test Vectors
+// ═══════════════════════════════════════════════════════════════
+
+// CanonicalTestTime is the frozen clock behind CanonicalTestDocument.
+var CanonicalTestTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CanonicalTestDocument returns the shared interop fixture for et: a
+// minimal valid document with a deterministic LCT ID, key, and subject,
+// every timestamp set to CanonicalTestTime, and whatever entity-specific
+// fields validation requires. The same entity type always yields the same
+// CanonicalBytes, independent of the configured namespace and clock.
+func CanonicalTestDocument(et EntityType) (*Document, error) {
+	if !isValidEntityType(et) {
+		return nil, fmt.Errorf("invalid entity type %q", et)
+	}
+	key := sha256.Sum256([]byte("lct-test-vector:" + string(et)))
+	b := NewBuilder(et, "canonical").
+		WithNamespace(DefaultNamespace).
+		WithBinding("f"+hex.EncodeToString(key[:]), "cose:test_vector_proof").
+		WithSubjectFromKey().
+		WithBirthCertificate(
+			"lct:web4:society:genesis",
+			"lct:web4:role:citizen:"+string(et),
+			BirthPlatform,
+			[]string{"lct:web4:witness:w1", "lct:web4:witness:w2", "lct:web4:witness:w3"},
+		).
+		AddCapability("read:lct")
+	if et == EntityService {
+		b.WithConstraints(map[string]interface{}{
+			ConstraintSLA: map[string]interface{}{"uptime": 0.99, "latency_ms": 250.0},
+		})
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	doc := b.BuildUnsafe()
+	doc.LCTID = fmt.Sprintf("lct:%s:%s:%s", DefaultNamespace, et, simpleHash("canonical:"+string(et)))
+	ts := CanonicalTestTime.Format(time.RFC3339)
+	doc.mapTimestamps(func(string) string { return ts })
+
+	result := ValidateDocumentWithOptions(doc, ValidateOptions{
+		Namespace: DefaultNamespace,
+		Now:       func() time.Time { return CanonicalTestTime },
+	})
+	if !result.Valid {
+		return nil, &BuildError{Result: result}
+	}
+	return doc, nil
+}
@@ -0,0 +1,186 @@
+package lct
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden canonical vectors in testdata")
+
+// ═══════════════════════════════════════════════════════════════
+// Canonical Encoding Tests
+// ═══════════════════════════════════════════════════════════════
+
+// canonicalVectors are the cross-language golden vectors committed under
+// testdata/canonical.
+func canonicalVectors() map[string]*Document {
+	unusual := minimalValidDoc()
+	unusual.Binding.CreatedAt = "2026-02-19T09:30:00.500+09:30"
+	unusual.MRH.Bound = []MRHBound{
+		{LCTID: "lct:web4:society:zeta", Type: BoundParent, TS: "2026-02-19T00:00:00Z"},
+		{LCTID: "lct:web4:society:alpha", Type: BoundParent, TS: "2026-02-18T19:00:00-05:00"},
+	}
+	unusual.V3.Valuation = 12500000
+	unusual.V3.SubDimensions = map[string]map[string]float64{"valuation": {"tiny": 0.0000001, "huge": 1e21}}
+	unusual.Attestations = []Attestation{{
+		Witness: "lct:web4:witness:w1",
+		Type:    "existence",
+		Sig:     "cose:sig",
+		TS:      "2026-02-19T00:00:00Z",
+		Claims:  map[string]interface{}{"note": "<tag> & \"quote\"\n\u00e9\u20ac\U0001F600", "\u00e9": 1, "z": true},
+	}}
+
+	return map[string]*Document{
+		"minimal": minimalValidDoc(),
+		"unusual": unusual,
+	}
+}
+
+// TestCanonicalBytesGolden checks each committed vector: the document in
+// NAME.input.json must canonicalize to exactly NAME.canonical.json. Run
+// with -update to regenerate both files from canonicalVectors.
+func TestCanonicalBytesGolden(t *testing.T) {
+	dir := filepath.Join("testdata", "canonical")
+	if *updateGolden {
+		for name, doc := range canonicalVectors() {
+			input, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical, err := doc.CanonicalBytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, name+".input.json"), append(input, '\n'), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, name+".canonical.json"), canonical, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.input.json"))
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("No golden vectors found in %s (err=%v)", dir, err)
+	}
+	for _, path := range inputs {
+		name := strings.TrimSuffix(filepath.Base(path), ".input.json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var doc Document
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("Unmarshal input failed: %v", err)
+			}
+			got, err := doc.CanonicalBytes()
+			if err != nil {
+				t.Fatalf("CanonicalBytes failed: %v", err)
+			}
+			want, err := os.ReadFile(filepath.Join(dir, name+".canonical.json"))
+			if err != nil {
+				t.Fatalf("Reading golden vector: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Canonical bytes differ from golden vector:\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestCanonicalBytesMinimalGolden(t *testing.T) {
+	got, err := minimalValidDoc().CanonicalBytes()
+	if err != nil {
+		t.Fatalf("CanonicalBytes failed: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "canonical", "minimal.canonical.json"))
+	if err != nil {
+		t.Fatalf("Reading golden vector: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("minimalValidDoc canonical bytes changed:\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestCanonicalBytesStable(t *testing.T) {
+	a := minimalValidDoc()
+	a.MRH.Paired = append(a.MRH.Paired, MRHPaired{LCTID: "lct:web4:ai:peer", PairingType: PairingOperational, TS: "2026-02-19T00:00:00Z"})
+	b := a.Clone()
+	b.MRH.Paired[0], b.MRH.Paired[1] = b.MRH.Paired[1], b.MRH.Paired[0]
+	b.Binding.CreatedAt = "2026-02-19T01:00:00+01:00"
+
+	ab, _ := a.CanonicalBytes()
+	bb, _ := b.CanonicalBytes()
+	if !bytes.Equal(ab, bb) {
+		t.Errorf("Expected reordered MRH and offset timestamps to canonicalize equally:\n%s\n%s", ab, bb)
+	}
+	if a.MRH.Paired[1].LCTID != "lct:web4:ai:peer" {
+		t.Error("CanonicalBytes should not modify the document")
+	}
+}
+
+func TestCanonicalBytesExtraNumbers(t *testing.T) {
+	a := minimalValidDoc()
+	a.Extra = map[string]json.RawMessage{
+		"x_ratio":  json.RawMessage(`1.0`),
+		"x_count":  json.RawMessage(`1E3`),
+		"x_zero":   json.RawMessage(`-0`),
+		"x_big":    json.RawMessage(`1e21`),
+		"x_small":  json.RawMessage(`0.0000001`),
+		"x_nested": json.RawMessage(`{"v":[2.50,-1.5e0]}`),
+	}
+	b := a.Clone()
+	b.Extra = map[string]json.RawMessage{
+		"x_ratio":  json.RawMessage(`1`),
+		"x_count":  json.RawMessage(`1000`),
+		"x_zero":   json.RawMessage(`0`),
+		"x_big":    json.RawMessage(`1E+21`),
+		"x_small":  json.RawMessage(`1e-7`),
+		"x_nested": json.RawMessage(`{"v":[2.5,-1.5]}`),
+	}
+
+	ab, err := a.CanonicalBytes()
+	if err != nil {
+		t.Fatalf("CanonicalBytes failed: %v", err)
+	}
+	bb, _ := b.CanonicalBytes()
+	if !bytes.Equal(ab, bb) {
+		t.Errorf("Expected equal numbers to canonicalize equally:\n%s\n%s", ab, bb)
+	}
+	for _, want := range []string{`"x_ratio":1,`, `"x_count":1000,`, `"x_zero":0`, `"x_big":1e+21,`, `"x_small":1e-7,`, `"x_nested":{"v":[2.5,-1.5]}`} {
+		if !bytes.Contains(ab, []byte(want)) {
+			t.Errorf("Expected %s in %s", want, ab)
+		}
+	}
+}
+
+func TestCanonicalTestDocumentAllEntityTypes(t *testing.T) {
+	for _, et := range ValidEntityTypes {
+		t.Run(string(et), func(t *testing.T) {
+			doc, err := CanonicalTestDocument(et)
+			if err != nil {
+				t.Fatalf("CanonicalTestDocument failed: %v", err)
+			}
+			if result := ValidateDocument(doc); !result.Valid {
+				t.Errorf("Expected valid document, got %v", result.Errors)
+			}
+			assertEqual(t, "entity_type", string(et), string(doc.Binding.EntityType))
+
+			again, _ := CanonicalTestDocument(et)
+			a, _ := doc.CanonicalBytes()
+			b, _ := again.CanonicalBytes()
+			assertEqual(t, "canonical bytes", string(a), string(b))
+		})
+	}
+
+	if _, err := CanonicalTestDocument("alien"); err == nil {
+		t.Error("Expected error for an invalid entity type")
+	}
+}
@@ -0,0 +1,9 @@
+package lct
+
+import "time"
+
+// Now is the clock used wherever the package writes or compares a
+// timestamp: builder output, tensors, attestations, lineage, and time-based
+// checks. Tests may replace it with a fixed clock for deterministic output
+// and must restore it afterwards.
+var Now = time.Now
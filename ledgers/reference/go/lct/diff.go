@@ -0,0 +1,409 @@
+package lct
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Document Diff
+// ═══════════════════════════════════════════════════════════════
+
+// FieldChange is a single difference between two documents. Path uses the
+// JSON field names, dotted for objects and indexed for arrays (e.g.
+// "policy.capabilities[1]"). Within a key, '~' is written as "~0", '.' as
+// "~1" and '[' as "~2", so keys such as dictionary terms may contain them.
+// Old or New is nil when the field was added or removed.
+type FieldChange struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// DiffDocuments returns the leaf-level changes from a to b, sorted by path.
+// Either document may be nil, in which case every field of the other is
+// reported as added or removed.
+func DiffDocuments(a, b *Document) ([]FieldChange, error) {
+	av, err := documentTree(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := documentTree(b)
+	if err != nil {
+		return nil, err
+	}
+	var changes []FieldChange
+	diffValues("", av, bv, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// documentTree decodes doc's JSON form into generic maps and slices.
+func documentTree(doc *Document) (interface{}, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffValues(path string, a, b interface{}, changes *[]FieldChange) {
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if (aIsMap || a == nil) && (bIsMap || b == nil) && (aIsMap || bIsMap) {
+		keys := map[string]bool{}
+		for k := range am {
+			keys[k] = true
+		}
+		for k := range bm {
+			keys[k] = true
+		}
+		for k := range keys {
+			child := pathKeyEscaper.Replace(k)
+			if path != "" {
+				child = path + "." + child
+			}
+			diffValues(child, am[k], bm[k], changes)
+		}
+		return
+	}
+
+	as, aIsSlice := a.([]interface{})
+	bs, bIsSlice := b.([]interface{})
+	if (aIsSlice || a == nil) && (bIsSlice || b == nil) && (aIsSlice || bIsSlice) {
+		n := len(as)
+		if len(bs) > n {
+			n = len(bs)
+		}
+		for i := 0; i < n; i++ {
+			var av, bv interface{}
+			if i < len(as) {
+				av = as[i]
+			}
+			if i < len(bs) {
+				bv = bs[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), av, bv, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, FieldChange{Path: path, Old: a, New: b})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Diff Apply
+// ═══════════════════════════════════════════════════════════════
+
+// ApplyFieldChanges returns a copy of base with changes applied, so that
+// ApplyFieldChanges(a, DiffDocuments(a, b)) reconstructs b. Each change is
+// checked against base first: if the current value at its path does not
+// equal Old, base has moved on since the diff was taken and an error is
+// returned without applying anything. base is not modified.
+//
+// Objects emptied by removals are dropped, since DiffDocuments does not
+// distinguish an empty object from an absent one. Arrays emptied by
+// removals are kept as [], so required lists such as policy.capabilities
+// survive. Applying changes that remove every field is an error.
+func ApplyFieldChanges(base *Document, changes []FieldChange) (*Document, error) {
+	tree, err := documentTree(base)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		segs, err := parseFieldPath(c.Path)
+		if err != nil {
+			return nil, err
+		}
+		if cur := lookupPath(tree, segs); !reflect.DeepEqual(cur, c.Old) {
+			return nil, fmt.Errorf("conflict at %s: expected %v, found %v", c.Path, c.Old, cur)
+		}
+		val := c.New
+		if val == nil {
+			val = removedField{}
+		}
+		if tree, err = setPath(tree, segs, val); err != nil {
+			return nil, fmt.Errorf("applying %s: %w", c.Path, err)
+		}
+	}
+	tree, emptied := pruneRemoved(tree)
+	if emptied || !hasValue(tree) {
+		return nil, fmt.Errorf("changes remove every field of the document")
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// removedField marks a value deleted by a change until pruneRemoved runs,
+// so array indices stay stable while changes are applied.
+type removedField struct{}
+
+// pathSegment is one step of a FieldChange path: an object key, or an
+// array index when index >= 0.
+type pathSegment struct {
+	key   string
+	index int
+}
+
+// parseFieldPath splits a path such as "mrh.bound[0].lct_id".
+func parseFieldPath(path string) ([]pathSegment, error) {
+	var segs []pathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field path %q: unterminated index", path)
+			}
+			n, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid field path %q: bad index %q", path, path[i+1:i+end])
+			}
+			segs = append(segs, pathSegment{index: n})
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			key, err := unescapePathKey(path[i : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid field path %q: %w", path, err)
+			}
+			segs = append(segs, pathSegment{key: key, index: -1})
+			i += end
+		}
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("invalid field path %q", path)
+	}
+	return segs, nil
+}
+
+// pathKeyEscaper escapes the characters FieldChange paths reserve.
+var pathKeyEscaper = strings.NewReplacer("~", "~0", ".", "~1", "[", "~2")
+
+// unescapePathKey reverses pathKeyEscaper.
+func unescapePathKey(s string) (string, error) {
+	if !strings.Contains(s, "~") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '~' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 == len(s) {
+			return "", fmt.Errorf("dangling escape in key %q", s)
+		}
+		i++
+		switch s[i] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('.')
+		case '2':
+			b.WriteByte('[')
+		default:
+			return "", fmt.Errorf("bad escape ~%c in key %q", s[i], s)
+		}
+	}
+	return b.String(), nil
+}
+
+// lookupPath returns the value at segs, or nil if any step is missing.
+func lookupPath(v interface{}, segs []pathSegment) interface{} {
+	for _, seg := range segs {
+		if seg.index >= 0 {
+			s, ok := v.([]interface{})
+			if !ok || seg.index >= len(s) {
+				return nil
+			}
+			v = s[seg.index]
+		} else {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = m[seg.key]
+		}
+		if _, ok := v.(removedField); ok {
+			return nil
+		}
+	}
+	return v
+}
+
+// setPath stores val at segs within v, creating intermediate objects and
+// arrays as needed, and returns the updated v.
+func setPath(v interface{}, segs []pathSegment, val interface{}) (interface{}, error) {
+	if len(segs) == 0 {
+		return val, nil
+	}
+	if _, ok := v.(removedField); ok {
+		v = nil
+	}
+	seg := segs[0]
+	if seg.index >= 0 {
+		s, ok := v.([]interface{})
+		if !ok && v != nil {
+			return nil, fmt.Errorf("index [%d] into non-array value", seg.index)
+		}
+		for len(s) <= seg.index {
+			s = append(s, nil)
+		}
+		child, err := setPath(s[seg.index], segs[1:], val)
+		if err != nil {
+			return nil, err
+		}
+		s[seg.index] = child
+		return s, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok && v != nil {
+		return nil, fmt.Errorf("field %q of non-object value", seg.key)
+	}
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+	child, err := setPath(m[seg.key], segs[1:], val)
+	if err != nil {
+		return nil, err
+	}
+	m[seg.key] = child
+	return m, nil
+}
+
+// hasValue reports whether v holds any value other than empty objects
+// and arrays.
+func hasValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case map[string]interface{}:
+		for _, child := range t {
+			if hasValue(child) {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		for _, child := range t {
+			if hasValue(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// pruneRemoved drops removed fields and reports whether v is a removed
+// field or an object left empty by removals. Arrays are never reported as
+// emptied.
+func pruneRemoved(v interface{}) (interface{}, bool) {
+	switch t := v.(type) {
+	case removedField:
+		return nil, true
+	case map[string]interface{}:
+		removed := false
+		for k, child := range t {
+			pruned, emptied := pruneRemoved(child)
+			if emptied {
+				delete(t, k)
+				removed = true
+				continue
+			}
+			t[k] = pruned
+		}
+		return t, removed && len(t) == 0
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for _, child := range t {
+			if pruned, emptied := pruneRemoved(child); !emptied {
+				out = append(out, pruned)
+			}
+		}
+		return out, false
+	}
+	return v, false
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Mutation Log
+// ═══════════════════════════════════════════════════════════════
+
+// MutationEntry records one change made to a document by an actor.
+type MutationEntry struct {
+	LCTID   string
+	Actor   string
+	TS      string
+	Changes []FieldChange
+}
+
+// MutationLog is an in-process audit trail of document mutations. It is
+// safe for concurrent use.
+type MutationLog struct {
+	mu      sync.Mutex
+	entries []MutationEntry
+}
+
+// Record diffs before and after and appends the changes, attributed to
+// actor and timestamped now. Mutations with no changes are not recorded.
+func (l *MutationLog) Record(before, after *Document, actor string) error {
+	changes, err := DiffDocuments(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	lctID := ""
+	if after != nil {
+		lctID = after.LCTID
+	} else if before != nil {
+		lctID = before.LCTID
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, MutationEntry{
+		LCTID:   lctID,
+		Actor:   actor,
+		TS:      Now().UTC().Format(time.RFC3339),
+		Changes: changes,
+	})
+	return nil
+}
+
+// Entries returns a copy of the recorded entries, oldest first.
+func (l *MutationLog) Entries() []MutationEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]MutationEntry(nil), l.entries...)
+}
@@ -0,0 +1,171 @@
+package lct
+
+import (
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Document Diff Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestDiffDocuments(t *testing.T) {
+	a := minimalValidDoc()
+	b := a.Clone()
+	b.Binding.PublicKey = "mb64newkey"
+	b.Policy.Capabilities = append(b.Policy.Capabilities, "write:lct")
+
+	changes, err := DiffDocuments(a, b)
+	if err != nil {
+		t.Fatalf("DiffDocuments failed: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", changes)
+	}
+	assertEqual(t, "path 0", "binding.public_key", changes[0].Path)
+	assertEqual(t, "old key", "mb64testkey", changes[0].Old.(string))
+	assertEqual(t, "path 1", "policy.capabilities[1]", changes[1].Path)
+	if changes[1].Old != nil || changes[1].New != "write:lct" {
+		t.Errorf("Expected added capability, got %+v", changes[1])
+	}
+
+	if same, _ := DiffDocuments(a, a.Clone()); len(same) != 0 {
+		t.Errorf("Expected no changes for identical documents, got %+v", same)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Diff Apply Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestApplyFieldChangesRoundTrip(t *testing.T) {
+	old := minimalValidDoc()
+	updated := old.Clone()
+	updated.Binding.PublicKey = "mb64newkey"
+	updated.Policy.Capabilities = append(updated.Policy.Capabilities, "write:lct")
+	updated.Policy.Constraints = map[string]interface{}{"max_rate": 5.0}
+	updated.BirthCert.BirthWitnesses = updated.BirthCert.BirthWitnesses[:2]
+	updated.V3 = nil
+
+	changes, err := DiffDocuments(old, updated)
+	if err != nil {
+		t.Fatalf("DiffDocuments failed: %v", err)
+	}
+	got, err := ApplyFieldChanges(old, changes)
+	if err != nil {
+		t.Fatalf("ApplyFieldChanges failed: %v", err)
+	}
+	if rest, _ := DiffDocuments(got, updated); len(rest) != 0 {
+		t.Errorf("Expected reconstructed document to equal target, differs by %+v", rest)
+	}
+	if got.V3 != nil {
+		t.Errorf("Expected removed v3_tensor to stay nil, got %+v", got.V3)
+	}
+	assertEqual(t, "base key untouched", "mb64testkey", old.Binding.PublicKey)
+
+	emptied := old.Clone()
+	emptied.Policy.Capabilities = []string{}
+	got, err = ApplyFieldChanges(old, mustDiff(t, old, emptied))
+	if err != nil {
+		t.Fatalf("ApplyFieldChanges emptying capabilities failed: %v", err)
+	}
+	if got.Policy.Capabilities == nil {
+		t.Error("Expected capabilities emptied by removals to stay [], got nil")
+	}
+	if result := ValidateDocument(got); !result.Valid {
+		t.Errorf("Expected reconstructed document to validate, got: %v", result.Errors)
+	}
+
+	if _, err := ApplyFieldChanges(old, mustDiff(t, old, nil)); err == nil {
+		t.Error("Expected error when every field is removed")
+	}
+
+	if fresh, err := ApplyFieldChanges(nil, mustDiff(t, nil, updated)); err != nil {
+		t.Fatalf("ApplyFieldChanges from nil failed: %v", err)
+	} else if rest, _ := DiffDocuments(fresh, updated); len(rest) != 0 {
+		t.Errorf("Expected document built from nil to equal target, differs by %+v", rest)
+	}
+}
+
+func TestApplyFieldChangesEscapedKeys(t *testing.T) {
+	old := docOfType(EntityDictionary, "lct:web4:dictionary:terms")
+	if err := old.AddTerm("web4.trust", "lct:web4:role:auditor"); err != nil {
+		t.Fatalf("AddTerm failed: %v", err)
+	}
+	updated := old.Clone()
+	updated.Policy.Constraints = cloneMap(old.Policy.Constraints)
+	updated.Policy.Constraints[ConstraintTerms] = map[string]interface{}{
+		"web4.trust": "lct:web4:role:reviewer",
+		"a[0]~b":     "lct:web4:role:citizen",
+	}
+
+	changes := mustDiff(t, old, updated)
+	got, err := ApplyFieldChanges(old, changes)
+	if err != nil {
+		t.Fatalf("ApplyFieldChanges failed: %v", err)
+	}
+	if rest, _ := DiffDocuments(got, updated); len(rest) != 0 {
+		t.Errorf("Expected reconstructed document to equal target, differs by %+v", rest)
+	}
+	if lctID, _ := got.LookupTerm("web4.trust"); lctID != "lct:web4:role:reviewer" {
+		t.Errorf("Expected dotted term to be modified in place, got %q", lctID)
+	}
+
+	if _, err := ApplyFieldChanges(old, []FieldChange{{Path: "policy.constraints.bad~9", New: 1.0}}); err == nil {
+		t.Error("Expected error for an invalid escape")
+	}
+}
+
+func TestApplyFieldChangesStaleBase(t *testing.T) {
+	old := minimalValidDoc()
+	updated := old.Clone()
+	updated.Binding.PublicKey = "mb64newkey"
+	changes := mustDiff(t, old, updated)
+
+	stale := old.Clone()
+	stale.Binding.PublicKey = "mb64otherkey"
+	if _, err := ApplyFieldChanges(stale, changes); err == nil || !contains(err.Error(), "conflict at binding.public_key") {
+		t.Errorf("Expected conflict on binding.public_key, got %v", err)
+	}
+}
+
+func mustDiff(t *testing.T, a, b *Document) []FieldChange {
+	t.Helper()
+	changes, err := DiffDocuments(a, b)
+	if err != nil {
+		t.Fatalf("DiffDocuments failed: %v", err)
+	}
+	return changes
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Mutation Log Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestMutationLogRecordsCapabilityAddition(t *testing.T) {
+	var log MutationLog
+	before := minimalValidDoc()
+	after := before.Clone()
+	after.Policy.Capabilities = append(after.Policy.Capabilities, "read:lct")
+
+	if err := log.Record(before, after, "lct:web4:society:genesis"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := log.Record(after, after.Clone(), "lct:web4:society:genesis"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	entries := log.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry (no-op mutation skipped), got %d", len(entries))
+	}
+	e := entries[0]
+	assertEqual(t, "actor", "lct:web4:society:genesis", e.Actor)
+	assertEqual(t, "lct_id", before.LCTID, e.LCTID)
+	if e.TS == "" {
+		t.Error("Expected entry timestamp")
+	}
+	if len(e.Changes) != 1 {
+		t.Fatalf("Expected 1 change, got %+v", e.Changes)
+	}
+	assertEqual(t, "path", "policy.capabilities[1]", e.Changes[0].Path)
+}
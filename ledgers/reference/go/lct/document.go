@@ -1,11 +1,15 @@
 package lct
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -50,8 +54,10 @@ type T3Tensor struct {
 	Temperament float64 `json:"temperament"`
 	// Optional domain-specific refinements
 	SubDimensions map[string]map[string]float64 `json:"sub_dimensions,omitempty"`
-	// Weighted composite score (0.0-1.0)
-	CompositeScore float64 `json:"composite_score,omitempty"`
+	// Composite weights by root dimension; DefaultT3Weights() when empty
+	Weights map[string]float64 `json:"weights,omitempty"`
+	// Weighted composite score (0.0-1.0); always serialized since 0.0 is legitimate
+	CompositeScore float64 `json:"composite_score"`
 	// When tensors were last computed
 	LastComputed string `json:"last_computed,omitempty"`
 	// LCT IDs of entities that computed these scores
@@ -68,8 +74,10 @@ type V3Tensor struct {
 	Validity float64 `json:"validity"`
 	// Optional domain-specific refinements
 	SubDimensions map[string]map[string]float64 `json:"sub_dimensions,omitempty"`
-	// Weighted composite score
-	CompositeScore float64 `json:"composite_score,omitempty"`
+	// Composite weights by root dimension; DefaultV3Weights() when empty
+	Weights map[string]float64 `json:"weights,omitempty"`
+	// Weighted composite score; always serialized since 0.0 is legitimate
+	CompositeScore float64 `json:"composite_score"`
 	// When tensors were last computed
 	LastComputed string `json:"last_computed,omitempty"`
 	// LCT IDs of entities that computed these scores
@@ -78,11 +86,19 @@ type V3Tensor struct {
 
 // Binding represents a cryptographic anchor for an LCT.
 type Binding struct {
-	EntityType    EntityType `json:"entity_type"`
-	PublicKey     string     `json:"public_key"`
-	HardwareAnchor string   `json:"hardware_anchor,omitempty"`
-	CreatedAt     string     `json:"created_at"`
-	BindingProof  string     `json:"binding_proof"`
+	EntityType     EntityType `json:"entity_type"`
+	PublicKey      string     `json:"public_key"`
+	HardwareAnchor string     `json:"hardware_anchor,omitempty"`
+	CreatedAt      string     `json:"created_at"`
+	BindingProof   string     `json:"binding_proof"`
+	// Co-signatures over the binding from additional authorities
+	AdditionalProofs []string `json:"additional_proofs,omitempty"`
+}
+
+// isZero reports whether no binding field is set.
+func (b *Binding) isZero() bool {
+	return b.EntityType == "" && b.PublicKey == "" && b.HardwareAnchor == "" &&
+		b.CreatedAt == "" && b.BindingProof == "" && len(b.AdditionalProofs) == 0
 }
 
 // BirthContext describes the context of an entity's birth.
@@ -138,6 +154,12 @@ const (
 	WitnessQuality   WitnessRole = "quality"
 )
 
+// ValidWitnessRoles lists all canonical witness roles for validation.
+var ValidWitnessRoles = []WitnessRole{
+	WitnessTime, WitnessAudit, WitnessOracle, WitnessPeer,
+	WitnessExistence, WitnessAction, WitnessState, WitnessQuality,
+}
+
 // MRHBound represents a permanent hierarchical attachment.
 type MRHBound struct {
 	LCTID string    `json:"lct_id"`
@@ -184,6 +206,17 @@ type Attestation struct {
 	Sig     string                 `json:"sig"`
 	TS      string                 `json:"ts"`
 	Claims  map[string]interface{} `json:"claims,omitempty"`
+	// Additional witnesses co-signing the same observation
+	CoSignatures []CoSig `json:"co_signatures,omitempty"`
+	// Single-use value binding the attestation to one context (replay protection)
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// CoSig is an additional witness signature over an attestation.
+type CoSig struct {
+	Witness string `json:"witness"`
+	Sig     string `json:"sig"`
+	TS      string `json:"ts"`
 }
 
 // LineageReason describes why a lineage event occurred.
@@ -230,35 +263,118 @@ type Revocation struct {
 // Document is a complete Linked Context Token (LCT) document.
 //
 // Required: LCTID, Subject, Binding, BirthCert, MRH, Policy
-// Optional: T3, V3, Attestations, Lineage, Revocation
+// Optional: T3, V3, Attestations, Lineage, Revocation, TrustHistory
+//
+// Top-level fields from newer schemas are kept in Extra so that loading and
+// saving a document never loses data.
 type Document struct {
-	LCTID        string            `json:"lct_id"`
-	Subject      string            `json:"subject"`
-	Binding      Binding           `json:"binding"`
-	BirthCert    BirthCertificate  `json:"birth_certificate"`
-	MRH          MRH               `json:"mrh"`
-	Policy       Policy            `json:"policy"`
-	T3           *T3Tensor         `json:"t3_tensor,omitempty"`
-	V3           *V3Tensor         `json:"v3_tensor,omitempty"`
-	Attestations []Attestation     `json:"attestations,omitempty"`
-	Lineage      []LineageEntry    `json:"lineage,omitempty"`
-	Revocation   *Revocation       `json:"revocation,omitempty"`
+	SchemaVersion int              `json:"schema_version,omitempty"`
+	LCTID         string           `json:"lct_id"`
+	Subject       string           `json:"subject"`
+	Binding       Binding          `json:"binding"`
+	BirthCert     BirthCertificate `json:"birth_certificate"`
+	MRH           MRH              `json:"mrh"`
+	Policy        Policy           `json:"policy"`
+	T3            *T3Tensor        `json:"t3_tensor,omitempty"`
+	V3            *V3Tensor        `json:"v3_tensor,omitempty"`
+	Attestations  []Attestation    `json:"attestations,omitempty"`
+	Lineage       []LineageEntry   `json:"lineage,omitempty"`
+	Revocation    *Revocation      `json:"revocation,omitempty"`
+	TrustHistory  []TrustSnapshot  `json:"trust_history,omitempty"`
+	// Unrecognized top-level fields, preserved across unmarshal/marshal
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ═══════════════════════════════════════════════════════════════
 // Tensor Operations
 // ═══════════════════════════════════════════════════════════════
 
-// ComputeT3Composite calculates the weighted composite score for a T3 tensor.
-// Weights: talent=0.4, training=0.3, temperament=0.3
+var (
+	defaultT3Weights = map[string]float64{"talent": 0.4, "training": 0.3, "temperament": 0.3}
+	defaultV3Weights = map[string]float64{"valuation": 0.3, "veracity": 0.35, "validity": 0.35}
+)
+
+// DefaultT3Weights returns a copy of the canonical T3 composite weights.
+func DefaultT3Weights() map[string]float64 {
+	return cloneWeights(defaultT3Weights)
+}
+
+// DefaultV3Weights returns a copy of the canonical V3 composite weights.
+func DefaultV3Weights() map[string]float64 {
+	return cloneWeights(defaultV3Weights)
+}
+
+// tensorPrecision is the number of decimal places computed tensor scores
+// are rounded to, so float drift such as 0.30000000000000004 never reaches
+// the serialized form and hashes agree across implementations.
+const tensorPrecision = 6
+
+// maxTensorPrecision bounds RoundTensorsTo; float64 carries no more
+// meaningful decimal places for scores in 0.0-1.0.
+const maxTensorPrecision = 15
+
+// roundScore rounds v to tensorPrecision decimal places.
+func roundScore(v float64) float64 {
+	return roundTo(v, tensorPrecision)
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow10(places)
+	return math.Round(v*p) / p
+}
+
+// ComputeT3Composite calculates the weighted composite score for a T3 tensor,
+// using the weights persisted on the tensor so the score is reproducible.
+// Without persisted weights: talent=0.4, training=0.3, temperament=0.3.
+// The tensor is not modified.
 func ComputeT3Composite(t3 *T3Tensor) float64 {
-	return t3.Talent*0.4 + t3.Training*0.3 + t3.Temperament*0.3
+	w := t3.Weights
+	if len(w) == 0 {
+		w = defaultT3Weights
+	}
+	return roundScore(t3.Talent*w["talent"] + t3.Training*w["training"] + t3.Temperament*w["temperament"])
 }
 
-// ComputeV3Composite calculates the weighted composite score for a V3 tensor.
-// Weights: valuation=0.3, veracity=0.35, validity=0.35
+// ComputeV3Composite calculates the weighted composite score for a V3 tensor,
+// using the weights persisted on the tensor so the score is reproducible.
+// Without persisted weights: valuation=0.3, veracity=0.35, validity=0.35.
+// The tensor is not modified.
 func ComputeV3Composite(v3 *V3Tensor) float64 {
-	return v3.Valuation*0.3 + v3.Veracity*0.35 + v3.Validity*0.35
+	w := v3.Weights
+	if len(w) == 0 {
+		w = defaultV3Weights
+	}
+	return roundScore(v3.Valuation*w["valuation"] + v3.Veracity*w["veracity"] + v3.Validity*w["validity"])
+}
+
+// Blend moves the tensor toward observation by alpha (0.0-1.0) on each root
+// dimension, new = (1-alpha)*old + alpha*obs rounded to 6 decimal places,
+// then recomputes the composite.
+// alpha=0 keeps the current values and alpha=1 adopts the observation.
+func (t3 *T3Tensor) Blend(observation T3Tensor, alpha float64) error {
+	if alpha < 0 || alpha > 1 || math.IsNaN(alpha) {
+		return fmt.Errorf("blend alpha must be 0.0-1.0, got %g", alpha)
+	}
+	t3.Talent = roundScore((1-alpha)*t3.Talent + alpha*observation.Talent)
+	t3.Training = roundScore((1-alpha)*t3.Training + alpha*observation.Training)
+	t3.Temperament = roundScore((1-alpha)*t3.Temperament + alpha*observation.Temperament)
+	t3.CompositeScore = ComputeT3Composite(t3)
+	t3.LastComputed = Now().UTC().Format(time.RFC3339)
+	return nil
+}
+
+// Blend moves the tensor toward observation by alpha (0.0-1.0) on each root
+// dimension, as T3Tensor.Blend does, then recomputes the composite.
+func (v3 *V3Tensor) Blend(observation V3Tensor, alpha float64) error {
+	if alpha < 0 || alpha > 1 || math.IsNaN(alpha) {
+		return fmt.Errorf("blend alpha must be 0.0-1.0, got %g", alpha)
+	}
+	v3.Valuation = roundScore((1-alpha)*v3.Valuation + alpha*observation.Valuation)
+	v3.Veracity = roundScore((1-alpha)*v3.Veracity + alpha*observation.Veracity)
+	v3.Validity = roundScore((1-alpha)*v3.Validity + alpha*observation.Validity)
+	v3.CompositeScore = ComputeV3Composite(v3)
+	v3.LastComputed = Now().UTC().Format(time.RFC3339)
+	return nil
 }
 
 // DefaultT3 creates a neutral starting T3 tensor (all 0.5).
@@ -268,7 +384,7 @@ func DefaultT3() T3Tensor {
 		Training:       0.5,
 		Temperament:    0.5,
 		CompositeScore: 0.5,
-		LastComputed:   time.Now().UTC().Format(time.RFC3339),
+		LastComputed:   Now().UTC().Format(time.RFC3339),
 	}
 }
 
@@ -279,7 +395,7 @@ func DefaultV3() V3Tensor {
 		Veracity:       0.5,
 		Validity:       0.5,
 		CompositeScore: 0.35,
-		LastComputed:   time.Now().UTC().Format(time.RFC3339),
+		LastComputed:   Now().UTC().Format(time.RFC3339),
 	}
 }
 
@@ -291,12 +407,13 @@ func MigrateT3FromLegacy6D(competence, reliability, consistency, witnesses, line
 	temperament := (witnesses + alignment) / 2.0
 
 	t3 := T3Tensor{
-		Talent:      clamp01(talent),
-		Training:    clamp01(training),
-		Temperament: clamp01(temperament),
+		Talent:      roundScore(clamp01(talent)),
+		Training:    roundScore(clamp01(training)),
+		Temperament: roundScore(clamp01(temperament)),
+		Weights:     DefaultT3Weights(),
 	}
 	t3.CompositeScore = ComputeT3Composite(&t3)
-	t3.LastComputed = time.Now().UTC().Format(time.RFC3339)
+	t3.LastComputed = Now().UTC().Format(time.RFC3339)
 	return t3
 }
 
@@ -307,15 +424,60 @@ func MigrateV3FromLegacy6D(energy, contribution, stewardship, network, reputatio
 	validity := (stewardship + network + temporal) / 3.0
 
 	v3 := V3Tensor{
-		Valuation: clamp01(valuation),
-		Veracity:  clamp01(veracity),
-		Validity:  clamp01(validity),
+		Valuation: roundScore(clamp01(valuation)),
+		Veracity:  roundScore(clamp01(veracity)),
+		Validity:  roundScore(clamp01(validity)),
+		Weights:   DefaultV3Weights(),
 	}
 	v3.CompositeScore = ComputeV3Composite(&v3)
-	v3.LastComputed = time.Now().UTC().Format(time.RFC3339)
+	v3.LastComputed = Now().UTC().Format(time.RFC3339)
 	return v3
 }
 
+// RoundTensors rounds the root dimensions, sub-dimensions and composite
+// scores of the document's T3 and V3 tensors to 6 decimal places, the
+// precision of this package's compute functions. Call it on documents
+// assembled by hand before hashing; tensors produced by the compute
+// functions are already rounded. Rounding is idempotent.
+func (doc *Document) RoundTensors() {
+	doc.roundTensors(tensorPrecision)
+}
+
+// RoundTensorsTo is RoundTensors with an explicit number of decimal
+// places, 0-15.
+func (doc *Document) RoundTensorsTo(places int) error {
+	if places < 0 || places > maxTensorPrecision {
+		return fmt.Errorf("tensor precision must be 0-%d decimal places, got %d", maxTensorPrecision, places)
+	}
+	doc.roundTensors(places)
+	return nil
+}
+
+func (doc *Document) roundTensors(places int) {
+	if t3 := doc.T3; t3 != nil {
+		t3.Talent = roundTo(t3.Talent, places)
+		t3.Training = roundTo(t3.Training, places)
+		t3.Temperament = roundTo(t3.Temperament, places)
+		t3.CompositeScore = roundTo(t3.CompositeScore, places)
+		roundSubDimensions(t3.SubDimensions, places)
+	}
+	if v3 := doc.V3; v3 != nil {
+		v3.Valuation = roundTo(v3.Valuation, places)
+		v3.Veracity = roundTo(v3.Veracity, places)
+		v3.Validity = roundTo(v3.Validity, places)
+		v3.CompositeScore = roundTo(v3.CompositeScore, places)
+		roundSubDimensions(v3.SubDimensions, places)
+	}
+}
+
+func roundSubDimensions(subs map[string]map[string]float64, places int) {
+	for _, dims := range subs {
+		for k, v := range dims {
+			dims[k] = roundTo(v, places)
+		}
+	}
+}
+
 func clamp01(v float64) float64 {
 	return math.Max(0, math.Min(1, v))
 }
@@ -331,36 +493,278 @@ type DocValidationResult struct {
 	Warnings []string
 }
 
+// String renders the result as a human-readable report: errors, then
+// warnings, then a final VALID/INVALID summary line.
+//
+//	Errors (1):
+//	  [1] Missing binding.public_key
+//	Warnings (1):
+//	  [1] No permanent birth_certificate pairing found in mrh.paired
+//	INVALID: 1 error(s), 1 warning(s)
+func (r DocValidationResult) String() string {
+	var b strings.Builder
+	writeSection := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		width := len(fmt.Sprint(len(items)))
+		fmt.Fprintf(&b, "%s (%d):\n", title, len(items))
+		for i, item := range items {
+			fmt.Fprintf(&b, "  [%*d] %s\n", width, i+1, item)
+		}
+	}
+	writeSection("Errors", r.Errors)
+	writeSection("Warnings", r.Warnings)
+
+	status := "VALID"
+	if !r.Valid {
+		status = "INVALID"
+	}
+	fmt.Fprintf(&b, "%s: %d error(s), %d warning(s)\n", status, len(r.Errors), len(r.Warnings))
+	return b.String()
+}
+
+// WriteTo writes the String report to w, implementing io.WriterTo.
+func (r DocValidationResult) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, r.String())
+	return int64(n), err
+}
+
 var (
-	lctIDPattern  = regexp.MustCompile(`^lct:web4:[A-Za-z0-9_:-]+$`)
 	subjectPattern = regexp.MustCompile(`^did:web4:(key|method):[A-Za-z0-9_-]+$`)
 )
 
-func isValidEntityType(et EntityType) bool {
+// validEntityTypes indexes ValidEntityTypes for constant-time lookup.
+var validEntityTypes = func() map[EntityType]struct{} {
+	m := make(map[EntityType]struct{}, len(ValidEntityTypes))
 	for _, t := range ValidEntityTypes {
-		if t == et {
-			return true
+		m[t] = struct{}{}
+	}
+	return m
+}()
+
+func isValidEntityType(et EntityType) bool {
+	_, ok := validEntityTypes[et]
+	return ok
+}
+
+// validWitnessRoles indexes ValidWitnessRoles for constant-time lookup.
+var validWitnessRoles = func() map[WitnessRole]struct{} {
+	m := make(map[WitnessRole]struct{}, len(ValidWitnessRoles))
+	for _, r := range ValidWitnessRoles {
+		m[r] = struct{}{}
+	}
+	return m
+}()
+
+func isValidWitnessRole(r WitnessRole) bool {
+	_, ok := validWitnessRoles[r]
+	return ok
+}
+
+// NormalizeEntityType maps a loosely authored entity type ("AI", " Human")
+// to its canonical form. It returns false if no canonical type matches.
+func NormalizeEntityType(s string) (EntityType, bool) {
+	et := EntityType(strings.ToLower(strings.TrimSpace(s)))
+	if !isValidEntityType(et) {
+		return "", false
+	}
+	return et, true
+}
+
+// NormalizeDocument repairs casing in hand-authored documents before
+// validation: a recognizable Binding.EntityType is rewritten to its
+// canonical form and anything else is left for ValidateDocument to report.
+// ValidateDocument itself stays strict and never normalizes.
+func NormalizeDocument(doc *Document) {
+	if et, ok := NormalizeEntityType(string(doc.Binding.EntityType)); ok {
+		doc.Binding.EntityType = et
+	}
+}
+
+// issueCapacity is the capacity reserved when ValidateDocument records its
+// first error or warning, so documents with several problems grow once.
+const issueCapacity = 8
+
+// appendIssue appends msg to list, reserving issueCapacity on first use.
+// Valid documents with no issues allocate nothing.
+func appendIssue(list []string, msg string) []string {
+	if list == nil {
+		list = make([]string, 0, issueCapacity)
+	}
+	return append(list, msg)
+}
+
+// appendSubDimensionIssues reports sub-dimension values outside 0.0-1.0,
+// in sorted key order. Children of the unbounded root dimension (V3
+// valuation) may exceed 1.0 but must still be >= 0.
+func appendSubDimensionIssues(list []string, tensor string, subs map[string]map[string]float64, unbounded string) []string {
+	roots := make([]string, 0, len(subs))
+	for root := range subs {
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+	for _, root := range roots {
+		keys := make([]string, 0, len(subs[root]))
+		for k := range subs[root] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := subs[root][k]
+			path := fmt.Sprintf("%s.sub_dimensions.%s.%s", tensor, root, k)
+			if root == unbounded {
+				if v < 0 {
+					list = appendIssue(list, path+" must be >= 0")
+				}
+			} else if v < 0 || v > 1 {
+				list = appendIssue(list, path+" must be 0.0-1.0")
+			}
+		}
+	}
+	return list
+}
+
+// weightSumTolerance is how far composite weights may sum from 1.0.
+const weightSumTolerance = 0.001
+
+// appendDuplicateIDIssues reports each LCT ID listed more than once in an
+// MRH relationship list. The quadratic scan keeps the common short lists
+// allocation-free.
+func appendDuplicateIDIssues[T any](list []string, field string, entries []T, id func(T) string) []string {
+	for i := range entries {
+		earlier := 0
+		for j := 0; j < i; j++ {
+			if id(entries[j]) == id(entries[i]) {
+				earlier++
+			}
+		}
+		if earlier == 1 {
+			list = appendIssue(list, fmt.Sprintf("Duplicate LCT ID in %s: %q", field, id(entries[i])))
+		}
+	}
+	return list
+}
+
+// appendWeightIssues reports persisted composite weights that name unknown
+// dimensions, are negative, or do not sum to ~1.0. Empty weights use the
+// canonical defaults and are always valid.
+func appendWeightIssues(list []string, tensor string, weights, defaults map[string]float64) []string {
+	if len(weights) == 0 {
+		return list
+	}
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sum := 0.0
+	for _, k := range keys {
+		if _, ok := defaults[k]; !ok {
+			list = appendIssue(list, fmt.Sprintf("%s.weights.%s is not a root dimension", tensor, k))
 		}
+		if weights[k] < 0 {
+			list = appendIssue(list, fmt.Sprintf("%s.weights.%s must be >= 0", tensor, k))
+		}
+		sum += weights[k]
+	}
+	if math.Abs(sum-1) > weightSumTolerance {
+		list = appendIssue(list, fmt.Sprintf("%s.weights must sum to 1.0, got %g", tensor, sum))
 	}
-	return false
+	return list
 }
 
+// HardwareAnchorTypes lists the accepted EAT hardware anchor types.
+var HardwareAnchorTypes = []string{"tpm2", "tee", "sgx", "nitro"}
+
+// ValidateHardwareAnchor checks the structure of an EAT hardware anchor:
+// "eat:{type}:{token}" with a known anchor type and a non-empty token.
+// The token itself is not verified.
+func ValidateHardwareAnchor(anchor string) error {
+	parts := strings.SplitN(anchor, ":", 3)
+	if len(parts) < 1 || parts[0] != "eat" {
+		return fmt.Errorf("hardware anchor must start with \"eat:\", got %q", truncate(anchor, 20))
+	}
+	if len(parts) < 2 || parts[1] == "" {
+		return fmt.Errorf("hardware anchor missing type: expected \"eat:{type}:{token}\"")
+	}
+	known := false
+	for _, t := range HardwareAnchorTypes {
+		if parts[1] == t {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown hardware anchor type %q - must be %s", parts[1], strings.Join(HardwareAnchorTypes, "|"))
+	}
+	if len(parts) < 3 || parts[2] == "" {
+		return fmt.Errorf("hardware anchor missing token: expected \"eat:%s:{token}\"", parts[1])
+	}
+	return nil
+}
+
+// ValidateOptions adjusts ValidateDocumentWithOptions.
+type ValidateOptions struct {
+	// Namespace expected in lct_id (defaults to Namespace())
+	Namespace string
+	// Now returns the current time for future-timestamp checks
+	// (defaults to Now)
+	Now func() time.Time
+	// ClockSkew is how far in the future a timestamp may be before it is
+	// rejected (defaults to DefaultClockSkew)
+	ClockSkew time.Duration
+	// RequireTensors rejects documents carrying neither a T3 nor a V3
+	// tensor, for trust-critical deployments (tensors are optional in the
+	// base schema)
+	RequireTensors bool
+	// RequiredBirthWitnesses, when > 0, is the society's mandated minimum
+	// birth witness count; fewer is an error rather than a warning
+	RequiredBirthWitnesses int
+}
+
+// MinRecommendedBirthWitnesses is the birth witness count below which
+// ValidateDocument warns, per spec.
+var MinRecommendedBirthWitnesses = 3
+
+// DefaultClockSkew is the future-timestamp tolerance ValidateDocument uses
+// when ValidateOptions.ClockSkew is unset.
+const DefaultClockSkew = 5 * time.Minute
+
 // ValidateDocument validates an LCT Document against the schema rules.
 func ValidateDocument(doc *Document) DocValidationResult {
+	return ValidateDocumentWithOptions(doc, ValidateOptions{})
+}
+
+// ValidateDocumentWithOptions validates an LCT Document against the schema
+// rules, adjusted by opts.
+func ValidateDocumentWithOptions(doc *Document, opts ValidateOptions) DocValidationResult {
 	var errors, warnings []string
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = Namespace()
+	}
+	now := Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	skew := opts.ClockSkew
+	if skew == 0 {
+		skew = DefaultClockSkew
+	}
 
 	// Required fields
 	if doc.LCTID == "" {
-		errors = append(errors, "Missing required field: lct_id")
+		errors = appendIssue(errors, "Missing required field: lct_id")
 	}
 	if doc.Subject == "" {
-		errors = append(errors, "Missing required field: subject")
+		errors = appendIssue(errors, "Missing required field: subject")
 	}
-	if doc.Binding == (Binding{}) {
-		errors = append(errors, "Missing required field: binding")
+	if doc.Binding.isZero() {
+		errors = appendIssue(errors, "Missing required field: binding")
 	}
 	if doc.Policy.Capabilities == nil {
-		errors = append(errors, "Missing policy.capabilities")
+		errors = appendIssue(errors, "Missing policy.capabilities")
 	}
 
 	if len(errors) > 0 {
@@ -368,103 +772,197 @@ func ValidateDocument(doc *Document) DocValidationResult {
 	}
 
 	// LCT ID format
-	if !lctIDPattern.MatchString(doc.LCTID) {
-		errors = append(errors, fmt.Sprintf("Invalid lct_id format: %q", doc.LCTID))
+	if !lctIDPatternFor(namespace).MatchString(doc.LCTID) {
+		errors = appendIssue(errors, fmt.Sprintf("Invalid lct_id format: %q", doc.LCTID))
 	}
 
 	// Subject format
 	if !subjectPattern.MatchString(doc.Subject) {
-		errors = append(errors, fmt.Sprintf("Invalid subject format: %q", doc.Subject))
+		errors = appendIssue(errors, fmt.Sprintf("Invalid subject format: %q", doc.Subject))
 	}
 
 	// Binding validation
 	if !isValidEntityType(doc.Binding.EntityType) {
-		errors = append(errors, fmt.Sprintf("Invalid entity_type: %q", doc.Binding.EntityType))
+		errors = appendIssue(errors, fmt.Sprintf("Invalid entity_type: %q", doc.Binding.EntityType))
 	}
 	if doc.Binding.PublicKey == "" {
-		errors = append(errors, "Missing binding.public_key")
+		errors = appendIssue(errors, "Missing binding.public_key")
 	}
 	if doc.Binding.CreatedAt == "" {
-		errors = append(errors, "Missing binding.created_at")
+		errors = appendIssue(errors, "Missing binding.created_at")
 	}
 	if doc.Binding.BindingProof == "" {
-		errors = append(errors, "Missing binding.binding_proof")
+		errors = appendIssue(errors, "Missing binding.binding_proof")
+	}
+	for i, proof := range doc.Binding.AdditionalProofs {
+		if proof == "" {
+			errors = appendIssue(errors, fmt.Sprintf("Empty binding.additional_proofs[%d]", i))
+		}
+	}
+	if key, ok := subjectKey(doc.Subject); ok {
+		// A rotated binding keeps the subject derived from the genesis key
+		if genesis, rotated := doc.genesisPublicKey(); rotated {
+			if pub, err := DecodePublicKey(genesis); err == nil && !bytes.Equal(key, pub) {
+				errors = appendIssue(errors, fmt.Sprintf("Genesis key in lineage does not match the key in subject %q", doc.Subject))
+			}
+		} else if pub, err := DecodePublicKey(doc.Binding.PublicKey); err == nil && !bytes.Equal(key, pub) {
+			errors = appendIssue(errors, fmt.Sprintf("binding.public_key does not match the key in subject %q", doc.Subject))
+		}
+	}
+	if doc.Binding.HardwareAnchor != "" {
+		if err := ValidateHardwareAnchor(doc.Binding.HardwareAnchor); err != nil {
+			errors = appendIssue(errors, fmt.Sprintf("Invalid binding.hardware_anchor: %v", err))
+		}
 	}
 
 	// Birth certificate validation
 	bc := doc.BirthCert
 	if bc.IssuingSociety == "" {
-		errors = append(errors, "Missing birth_certificate.issuing_society")
+		errors = appendIssue(errors, "Missing birth_certificate.issuing_society")
 	}
 	if bc.CitizenRole == "" {
-		errors = append(errors, "Missing birth_certificate.citizen_role")
+		errors = appendIssue(errors, "Missing birth_certificate.citizen_role")
 	}
 	if bc.Context == "" {
-		errors = append(errors, "Missing birth_certificate.context")
+		errors = appendIssue(errors, "Missing birth_certificate.context")
 	}
 	if bc.BirthTimestamp == "" {
-		errors = append(errors, "Missing birth_certificate.birth_timestamp")
+		errors = appendIssue(errors, "Missing birth_certificate.birth_timestamp")
 	}
 	if len(bc.BirthWitnesses) == 0 {
-		errors = append(errors, "birth_certificate.birth_witnesses must have at least 1 entry")
+		errors = appendIssue(errors, "birth_certificate.birth_witnesses must have at least 1 entry")
+	}
+	if n := len(bc.BirthWitnesses); n > 0 && n < opts.RequiredBirthWitnesses {
+		errors = appendIssue(errors, fmt.Sprintf("birth_certificate.birth_witnesses must have at least %d entries, got %d", opts.RequiredBirthWitnesses, n))
+	} else if n > 0 && n < MinRecommendedBirthWitnesses {
+		warnings = appendIssue(warnings, fmt.Sprintf("birth_certificate.birth_witnesses should have at least %d entries per spec", MinRecommendedBirthWitnesses))
+	}
+
+	// Creation timestamps must not be in the future beyond the clock skew
+	latest := now().Add(skew)
+	if ts, err := time.Parse(time.RFC3339, doc.Binding.CreatedAt); err == nil && ts.After(latest) {
+		errors = appendIssue(errors, fmt.Sprintf("binding.created_at is in the future: %s", doc.Binding.CreatedAt))
 	}
-	if len(bc.BirthWitnesses) > 0 && len(bc.BirthWitnesses) < 3 {
-		warnings = append(warnings, "birth_certificate.birth_witnesses should have at least 3 entries per spec")
+	if ts, err := time.Parse(time.RFC3339, bc.BirthTimestamp); err == nil && ts.After(latest) {
+		errors = appendIssue(errors, fmt.Sprintf("birth_certificate.birth_timestamp is in the future: %s", bc.BirthTimestamp))
 	}
 
 	// MRH validation
 	if len(doc.MRH.Paired) == 0 {
-		errors = append(errors, "mrh.paired must have at least 1 entry")
+		errors = appendIssue(errors, "mrh.paired must have at least 1 entry")
 	}
 	if doc.MRH.HorizonDepth < 1 || doc.MRH.HorizonDepth > 10 {
-		errors = append(errors, fmt.Sprintf("mrh.horizon_depth must be 1-10, got %d", doc.MRH.HorizonDepth))
+		errors = appendIssue(errors, fmt.Sprintf("mrh.horizon_depth must be 1-10, got %d", doc.MRH.HorizonDepth))
 	}
 
-	// Check for permanent citizen pairing
+	// Each relationship list names an entity at most once; the same ID may
+	// still appear in different lists
+	errors = appendDuplicateIDIssues(errors, "mrh.bound", doc.MRH.Bound, func(b MRHBound) string { return b.LCTID })
+	errors = appendDuplicateIDIssues(errors, "mrh.paired", doc.MRH.Paired, func(p MRHPaired) string { return p.LCTID })
+	errors = appendDuplicateIDIssues(errors, "mrh.witnessing", doc.MRH.Witnessing, func(w MRHWitnessing) string { return w.LCTID })
+	for i, w := range doc.MRH.Witnessing {
+		if !isValidWitnessRole(w.Role) {
+			errors = appendIssue(errors, fmt.Sprintf("Invalid mrh.witnessing[%d].role: %q", i, w.Role))
+		}
+	}
+
+	// Check for permanent citizen pairing, which must pair with the
+	// certificate's citizen role
 	hasCitizenPairing := false
 	for _, p := range doc.MRH.Paired {
-		if p.PairingType == PairingBirthCertificate && p.Permanent {
+		if p.PairingType != PairingBirthCertificate {
+			continue
+		}
+		if p.Permanent {
 			hasCitizenPairing = true
-			break
+		}
+		if bc.CitizenRole != "" && p.LCTID != bc.CitizenRole {
+			errors = appendIssue(errors, fmt.Sprintf("mrh.paired birth_certificate pairing %q does not match birth_certificate.citizen_role %q", p.LCTID, bc.CitizenRole))
 		}
 	}
 	if !hasCitizenPairing {
-		warnings = append(warnings, "No permanent birth_certificate pairing found in mrh.paired")
+		warnings = appendIssue(warnings, "No permanent birth_certificate pairing found in mrh.paired")
+	}
+
+	// Duplicate capabilities (hand-authored documents; the builder dedups)
+	seenCaps := make(map[string]bool, len(doc.Policy.Capabilities))
+	for _, c := range doc.Policy.Capabilities {
+		if seenCaps[c] {
+			warnings = appendIssue(warnings, fmt.Sprintf("Duplicate capability in policy.capabilities: %q", c))
+		}
+		seenCaps[c] = true
+		if err := ValidateCapability(c); err != nil {
+			warnings = appendIssue(warnings, fmt.Sprintf("Malformed capability in policy.capabilities: %v", err))
+		}
+	}
+
+	for _, c := range doc.Policy.Conflicts() {
+		warnings = appendIssue(warnings, fmt.Sprintf("Policy conflict: %s", c))
+	}
+
+	if opts.RequireTensors && doc.T3 == nil && doc.V3 == nil {
+		errors = appendIssue(errors, "Missing tensors: t3_tensor or v3_tensor is required")
 	}
 
 	// T3 tensor validation
 	if doc.T3 != nil {
 		if doc.T3.Talent < 0 || doc.T3.Talent > 1 {
-			errors = append(errors, "t3_tensor.talent must be 0.0-1.0")
+			errors = appendIssue(errors, "t3_tensor.talent must be 0.0-1.0")
 		}
 		if doc.T3.Training < 0 || doc.T3.Training > 1 {
-			errors = append(errors, "t3_tensor.training must be 0.0-1.0")
+			errors = appendIssue(errors, "t3_tensor.training must be 0.0-1.0")
 		}
 		if doc.T3.Temperament < 0 || doc.T3.Temperament > 1 {
-			errors = append(errors, "t3_tensor.temperament must be 0.0-1.0")
+			errors = appendIssue(errors, "t3_tensor.temperament must be 0.0-1.0")
 		}
+		errors = appendSubDimensionIssues(errors, "t3_tensor", doc.T3.SubDimensions, "")
+		errors = appendWeightIssues(errors, "t3_tensor", doc.T3.Weights, defaultT3Weights)
 	}
 
 	// V3 tensor validation
 	if doc.V3 != nil {
 		if doc.V3.Valuation < 0 {
-			errors = append(errors, "v3_tensor.valuation must be >= 0")
+			errors = appendIssue(errors, "v3_tensor.valuation must be >= 0")
 		}
 		if doc.V3.Veracity < 0 || doc.V3.Veracity > 1 {
-			errors = append(errors, "v3_tensor.veracity must be 0.0-1.0")
+			errors = appendIssue(errors, "v3_tensor.veracity must be 0.0-1.0")
 		}
 		if doc.V3.Validity < 0 || doc.V3.Validity > 1 {
-			errors = append(errors, "v3_tensor.validity must be 0.0-1.0")
+			errors = appendIssue(errors, "v3_tensor.validity must be 0.0-1.0")
+		}
+		errors = appendSubDimensionIssues(errors, "v3_tensor", doc.V3.SubDimensions, "valuation")
+		errors = appendWeightIssues(errors, "v3_tensor", doc.V3.Weights, defaultV3Weights)
+	}
+
+	// Entity-specific validation
+	if doc.Binding.EntityType == EntityService {
+		if _, _, err := doc.ServiceSLA(); err != nil {
+			errors = appendIssue(errors, err.Error())
+		}
+	}
+
+	for _, w := range doc.OrphanedWitnesses() {
+		warnings = appendIssue(warnings, fmt.Sprintf("mrh.witnessing entry %q has no matching attestation", w.LCTID))
+	}
+
+	for _, n := range doc.HasDuplicateAttestationNonces() {
+		warnings = appendIssue(warnings, fmt.Sprintf("Duplicate attestation nonce (possible replay): %q", n))
+	}
+
+	// Co-signature validation
+	for i := range doc.Attestations {
+		if err := doc.Attestations[i].ValidateCoSignatures(CoSignatureWindow); err != nil {
+			errors = appendIssue(errors, fmt.Sprintf("attestations[%d]: %v", i, err))
 		}
 	}
 
 	// Revocation validation
 	if doc.Revocation != nil && doc.Revocation.Status == RevocationRevoked {
 		if doc.Revocation.TS == "" {
-			warnings = append(warnings, "Revoked LCT should have revocation timestamp")
+			warnings = appendIssue(warnings, "Revoked LCT should have revocation timestamp")
 		}
 		if doc.Revocation.Reason == "" {
-			warnings = append(warnings, "Revoked LCT should have revocation reason")
+			warnings = appendIssue(warnings, "Revoked LCT should have revocation reason")
 		}
 	}
 
@@ -480,6 +978,8 @@ func ValidateDocument(doc *Document) DocValidationResult {
 // ═══════════════════════════════════════════════════════════════
 
 // ToURI converts an LCT Document to an LCT URI for network addressing.
+// The component and instance are sanitized so the result always parses
+// with ParseURI, provided network and role are valid URI segments.
 func (doc *Document) ToURI(network, role string) string {
 	if network == "" {
 		network = "local"
@@ -487,25 +987,491 @@ func (doc *Document) ToURI(network, role string) string {
 	if role == "" {
 		role = "default"
 	}
-	hash := doc.LCTID
-	parts := splitLast(hash, ":")
-	if parts[1] != "" {
-		hash = parts[1]
+	return fmt.Sprintf("lct://%s:%s:%s@%s", doc.uriComponent(), doc.uriInstance(), role, network)
+}
+
+// uriComponent returns the URI component segment for the document: its
+// entity type, lowercased and sanitized.
+func (doc *Document) uriComponent() string {
+	return sanitizeURISegment(strings.ToLower(string(doc.Binding.EntityType)), false)
+}
+
+// uriInstance returns the URI instance segment for the document: the last
+// non-empty segment of its LCT ID, sanitized.
+func (doc *Document) uriInstance() string {
+	id := strings.TrimRight(doc.LCTID, ":")
+	return sanitizeURISegment(id[strings.LastIndex(id, ":")+1:], true)
+}
+
+// sanitizeURISegment replaces characters a URI name segment does not allow
+// with '-' and strips leading separators; it returns "unknown" if nothing
+// remains. Underscores and uppercase are kept only for instance and role
+// segments (allowUpper).
+func sanitizeURISegment(s string, allowUpper bool) string {
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-':
+		case allowUpper && (c >= 'A' && c <= 'Z' || c == '_'):
+		default:
+			b[i] = '-'
+		}
+	}
+	if out := strings.TrimLeft(string(b), "-_"); out != "" {
+		return out
+	}
+	return "unknown"
+}
+
+// IdentityFromDocument derives a fully-populated Identity for doc, so
+// BuildURI emits its trust threshold, capabilities, and subject DID:
+//
+//   - TrustThreshold is the T3 composite (recomputed if zero), truncated to
+//     3 decimal places so it never exceeds the actual composite; -1 without T3
+//   - Capabilities are copied from the policy
+//   - PublicKeyHash is the subject DID
+func IdentityFromDocument(doc *Document, network, role string) *Identity {
+	if network == "" {
+		network = "local"
+	}
+	if role == "" {
+		role = "default"
+	}
+	threshold := -1.0
+	if composite, err := doc.T3Composite(); err == nil {
+		threshold = math.Floor(clamp01(composite)*1000) / 1000
+	}
+	return &Identity{
+		Component:      doc.uriComponent(),
+		Instance:       doc.uriInstance(),
+		Role:           role,
+		Network:        network,
+		Version:        "1.0.0",
+		TrustThreshold: threshold,
+		Capabilities:   cloneStrings(doc.Policy.Capabilities),
+		PublicKeyHash:  doc.Subject,
 	}
-	return fmt.Sprintf("lct://%s:%s:%s@%s", doc.Binding.EntityType, hash, role, network)
 }
 
 // Hash returns the SHA-256 hash of the document's canonical JSON form.
+// MRH relationships are canonically ordered first, so documents listing
+// the same relationships in different order hash equally.
 func (doc *Document) Hash() string {
-	data, _ := json.Marshal(doc)
+	c := *doc
+	c.MRH = copyMRHSlices(doc.MRH)
+	c.Canonicalize()
+	data, _ := json.Marshal(&c)
+	h := sha256.Sum256(data)
+	return fmt.Sprintf("%x", h)
+}
+
+// HashExcludingAttestations is Hash computed with the attestations removed.
+// For append-heavy workflows it stays stable while attestations are added,
+// so it can be cached and combined with AttestationsHash via CompositeHash.
+func (doc *Document) HashExcludingAttestations() string {
+	c := *doc
+	c.Attestations = nil
+	return c.Hash()
+}
+
+// AttestationsHash returns the SHA-256 hash of the attestations' JSON form.
+// Nil and empty lists hash equally.
+func AttestationsHash(atts []Attestation) string {
+	if atts == nil {
+		atts = []Attestation{}
+	}
+	data, _ := json.Marshal(atts)
 	h := sha256.Sum256(data)
 	return fmt.Sprintf("%x", h)
 }
 
-func splitLast(s, sep string) [2]string {
-	idx := strings.LastIndex(s, sep)
-	if idx < 0 {
-		return [2]string{s, ""}
+// CompositeHash combines a base hash and an attestations hash into the
+// value returned by HashComposite: the SHA-256 of their concatenation.
+func CompositeHash(baseHash, attestationsHash string) string {
+	h := sha256.Sum256([]byte(baseHash + attestationsHash))
+	return fmt.Sprintf("%x", h)
+}
+
+// HashComposite hashes the document as CompositeHash of
+// HashExcludingAttestations and AttestationsHash. Callers holding a cached
+// base hash need only rehash the attestations after appending one.
+func (doc *Document) HashComposite() string {
+	return CompositeHash(doc.HashExcludingAttestations(), AttestationsHash(doc.Attestations))
+}
+
+// EqualsIgnoringTimestamps reports whether two documents have the same
+// semantic content, ignoring timestamp leaves (created_at, ts,
+// last_updated, last_computed, last_attestation, birth_timestamp) and MRH
+// ordering.
+func (doc *Document) EqualsIgnoringTimestamps(other *Document) bool {
+	if doc == nil || other == nil {
+		return doc == other
+	}
+	a, b := doc.Clone(), other.Clone()
+	a.stripTimestamps()
+	b.stripTimestamps()
+	return a.Hash() == b.Hash()
+}
+
+// stripTimestamps clears every timestamp leaf in place.
+func (doc *Document) stripTimestamps() {
+	doc.mapTimestamps(func(string) string { return "" })
+}
+
+// mapTimestamps replaces every timestamp leaf with f applied to it.
+func (doc *Document) mapTimestamps(f func(ts string) string) {
+	doc.Binding.CreatedAt = f(doc.Binding.CreatedAt)
+	doc.BirthCert.BirthTimestamp = f(doc.BirthCert.BirthTimestamp)
+	doc.MRH.LastUpdated = f(doc.MRH.LastUpdated)
+	for i := range doc.MRH.Bound {
+		doc.MRH.Bound[i].TS = f(doc.MRH.Bound[i].TS)
+	}
+	for i := range doc.MRH.Paired {
+		doc.MRH.Paired[i].TS = f(doc.MRH.Paired[i].TS)
+	}
+	for i := range doc.MRH.Witnessing {
+		doc.MRH.Witnessing[i].LastAttestation = f(doc.MRH.Witnessing[i].LastAttestation)
+	}
+	if doc.T3 != nil {
+		doc.T3.LastComputed = f(doc.T3.LastComputed)
+	}
+	if doc.V3 != nil {
+		doc.V3.LastComputed = f(doc.V3.LastComputed)
+	}
+	for i := range doc.Attestations {
+		doc.Attestations[i].TS = f(doc.Attestations[i].TS)
+		for j := range doc.Attestations[i].CoSignatures {
+			doc.Attestations[i].CoSignatures[j].TS = f(doc.Attestations[i].CoSignatures[j].TS)
+		}
+	}
+	for i := range doc.Lineage {
+		doc.Lineage[i].TS = f(doc.Lineage[i].TS)
+	}
+	if doc.Revocation != nil {
+		doc.Revocation.TS = f(doc.Revocation.TS)
+	}
+	for i := range doc.TrustHistory {
+		snap := &doc.TrustHistory[i]
+		snap.TS = f(snap.TS)
+		snap.T3.LastComputed = f(snap.T3.LastComputed)
+		snap.V3.LastComputed = f(snap.V3.LastComputed)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Binding Proofs
+// ═══════════════════════════════════════════════════════════════
+
+// VerifyAllBindingProofs runs verify over the primary binding proof and
+// every additional proof. It returns one error per failing proof, naming
+// the proof's field, or nil if all pass.
+func (doc *Document) VerifyAllBindingProofs(verify func(proof string) error) []error {
+	var errs []error
+	if err := verify(doc.Binding.BindingProof); err != nil {
+		errs = append(errs, fmt.Errorf("binding_proof: %w", err))
+	}
+	for i, proof := range doc.Binding.AdditionalProofs {
+		if err := verify(proof); err != nil {
+			errs = append(errs, fmt.Errorf("additional_proofs[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Key Rotation
+// ═══════════════════════════════════════════════════════════════
+
+// AttestationBindingRotation is the attestation type carrying the
+// continuity signature for a key rotation.
+const AttestationBindingRotation = "binding:rotation"
+
+// RotateBinding returns a copy of doc bound to a new key. The old key
+// proves continuity by signing over the new one: continuitySig is stored
+// in a binding:rotation attestation alongside both public keys, and a
+// rotation lineage entry records the previous public key as its parent, so
+// the chain of rotations leads back to the genesis key. Additional
+// binding proofs covered the old key and are dropped. Revoked documents
+// cannot be rotated.
+func RotateBinding(doc *Document, newPub string, newProof string, continuitySig string) (*Document, error) {
+	if doc.Revocation != nil && doc.Revocation.Status == RevocationRevoked {
+		return nil, fmt.Errorf("cannot rotate binding of revoked LCT %s", doc.LCTID)
+	}
+	if newPub == "" || newProof == "" {
+		return nil, fmt.Errorf("rotation requires a new public key and binding proof")
+	}
+	if continuitySig == "" {
+		return nil, fmt.Errorf("rotation requires a continuity signature from the previous key")
+	}
+	if newPub == doc.Binding.PublicKey {
+		return nil, fmt.Errorf("rotation must change the public key")
+	}
+
+	now := Now().UTC().Format(time.RFC3339)
+	rotated := doc.Clone()
+	oldPub := rotated.Binding.PublicKey
+	rotated.Binding.PublicKey = newPub
+	rotated.Binding.BindingProof = newProof
+	rotated.Binding.AdditionalProofs = nil
+	rotated.Binding.CreatedAt = now
+	rotated.Lineage = append(rotated.Lineage, LineageEntry{
+		Parent: oldPub,
+		Reason: LineageRotation,
+		TS:     now,
+	})
+	att, err := NewAttestation(doc.LCTID, AttestationBindingRotation, continuitySig, map[string]interface{}{
+		"previous_public_key": oldPub,
+		"new_public_key":      newPub,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rotated.Attestations = append(rotated.Attestations, att)
+	return rotated, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Copying
+// ═══════════════════════════════════════════════════════════════
+
+// Clone returns a deep copy of the document. Mutating the clone's slices,
+// maps, tensors, or revocation never affects the original.
+func (doc *Document) Clone() *Document {
+	if doc == nil {
+		return nil
+	}
+	c := *doc
+	c.Binding.AdditionalProofs = cloneStrings(doc.Binding.AdditionalProofs)
+	c.BirthCert.BirthWitnesses = cloneStrings(doc.BirthCert.BirthWitnesses)
+	c.MRH = copyMRHSlices(doc.MRH)
+	c.Policy.Capabilities = cloneStrings(doc.Policy.Capabilities)
+	c.Policy.Constraints = cloneMap(doc.Policy.Constraints)
+	if doc.T3 != nil {
+		t3 := cloneT3(*doc.T3)
+		c.T3 = &t3
+	}
+	if doc.V3 != nil {
+		v3 := cloneV3(*doc.V3)
+		c.V3 = &v3
+	}
+	if doc.Attestations != nil {
+		c.Attestations = make([]Attestation, len(doc.Attestations))
+		for i, a := range doc.Attestations {
+			a.Claims = cloneMap(a.Claims)
+			if a.CoSignatures != nil {
+				a.CoSignatures = append([]CoSig(nil), a.CoSignatures...)
+			}
+			c.Attestations[i] = a
+		}
+	}
+	if doc.Lineage != nil {
+		c.Lineage = append([]LineageEntry(nil), doc.Lineage...)
+	}
+	if doc.TrustHistory != nil {
+		c.TrustHistory = make([]TrustSnapshot, len(doc.TrustHistory))
+		for i, snap := range doc.TrustHistory {
+			c.TrustHistory[i] = TrustSnapshot{TS: snap.TS, T3: cloneT3(snap.T3), V3: cloneV3(snap.V3)}
+		}
+	}
+	if doc.Extra != nil {
+		c.Extra = make(map[string]json.RawMessage, len(doc.Extra))
+		for k, v := range doc.Extra {
+			c.Extra[k] = append(json.RawMessage(nil), v...)
+		}
+	}
+	if doc.Revocation != nil {
+		r := *doc.Revocation
+		c.Revocation = &r
+	}
+	return &c
+}
+
+// cloneT3 returns a deep copy of a T3 tensor value.
+func cloneT3(t3 T3Tensor) T3Tensor {
+	t3.SubDimensions = cloneSubDimensions(t3.SubDimensions)
+	t3.Weights = cloneWeights(t3.Weights)
+	t3.ComputationWitnesses = cloneStrings(t3.ComputationWitnesses)
+	return t3
+}
+
+// cloneV3 returns a deep copy of a V3 tensor value.
+func cloneV3(v3 V3Tensor) V3Tensor {
+	v3.SubDimensions = cloneSubDimensions(v3.SubDimensions)
+	v3.Weights = cloneWeights(v3.Weights)
+	v3.ComputationWitnesses = cloneStrings(v3.ComputationWitnesses)
+	return v3
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+func cloneSubDimensions(m map[string]map[string]float64) map[string]map[string]float64 {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]map[string]float64, len(m))
+	for root, children := range m {
+		c := make(map[string]float64, len(children))
+		for k, v := range children {
+			c[k] = v
+		}
+		out[root] = c
+	}
+	return out
+}
+
+func cloneWeights(m map[string]float64) map[string]float64 {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]float64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+// cloneValue deep-copies the container types that appear in decoded JSON
+// and hand-built constraint maps. Other values are copied by assignment.
+func cloneValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return cloneMap(t)
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			out[i] = cloneValue(item)
+		}
+		return out
+	case []string:
+		return cloneStrings(t)
+	case map[string]string:
+		out := make(map[string]string, len(t))
+		for k, s := range t {
+			out[k] = s
+		}
+		return out
+	case map[string]float64:
+		out := make(map[string]float64, len(t))
+		for k, f := range t {
+			out[k] = f
+		}
+		return out
+	}
+	return v
+}
+
+// ═══════════════════════════════════════════════════════════════
+// JSON Encoding
+// ═══════════════════════════════════════════════════════════════
+
+// documentJSONFields holds the top-level JSON keys Document declares.
+var documentJSONFields = func() map[string]bool {
+	fields := map[string]bool{}
+	t := reflect.TypeOf(Document{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// documentFields has Document's fields without its JSON methods.
+type documentFields Document
+
+// MarshalJSON encodes the document compactly with object keys sorted at
+// every level and strings and numbers written per RFC 8785, so equal
+// documents always encode to identical bytes. Extra fields captured by
+// UnmarshalJSON are re-emitted; they never override declared fields.
+func (doc Document) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(documentFields(doc))
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSONTree(data)
+	if err != nil {
+		return nil, err
+	}
+	obj := v.(map[string]interface{})
+	for k, raw := range doc.Extra {
+		if documentJSONFields[k] {
+			continue
+		}
+		if obj[k], err = decodeJSONTree(raw); err != nil {
+			return nil, fmt.Errorf("extra field %q: %w", k, err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONIndent is MarshalJSON indented with two spaces for human
+// inspection. Key order matches the compact form.
+func (doc *Document) MarshalJSONIndent() ([]byte, error) {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeJSONTree decodes data into generic values, keeping numbers as
+// json.Number so they re-encode exactly.
+func decodeJSONTree(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UnmarshalJSON decodes the document, capturing unrecognized top-level
+// fields in Extra.
+func (doc *Document) UnmarshalJSON(data []byte) error {
+	var fields documentFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	fields.Extra = nil
+	for k, v := range obj {
+		if documentJSONFields[k] {
+			continue
+		}
+		if fields.Extra == nil {
+			fields.Extra = map[string]json.RawMessage{}
+		}
+		fields.Extra[k] = v
 	}
-	return [2]string{s[:idx], s[idx+len(sep):]}
+	*doc = Document(fields)
+	return nil
 }
@@ -1,9 +1,14 @@
 package lct
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // ═══════════════════════════════════════════════════════════════
@@ -134,6 +139,60 @@ func TestValidateDocumentFewBirthWitnessesWarning(t *testing.T) {
 	}
 }
 
+func TestValidateDocumentFutureTimestamp(t *testing.T) {
+	now := time.Date(2026, 2, 19, 0, 0, 0, 0, time.UTC)
+	opts := ValidateOptions{Now: func() time.Time { return now }}
+
+	doc := minimalValidDoc()
+	doc.Binding.CreatedAt = now.Add(10 * time.Minute).Format(time.RFC3339)
+	result := ValidateDocumentWithOptions(doc, opts)
+	if result.Valid {
+		t.Fatal("Expected invalid for created_at 10 minutes in the future")
+	}
+	if !contains(result.Errors[0], "binding.created_at is in the future") {
+		t.Errorf("Expected future created_at error, got: %v", result.Errors)
+	}
+
+	doc = minimalValidDoc()
+	doc.BirthCert.BirthTimestamp = now.Add(10 * time.Minute).Format(time.RFC3339)
+	if result := ValidateDocumentWithOptions(doc, opts); result.Valid {
+		t.Error("Expected invalid for birth_timestamp 10 minutes in the future")
+	}
+}
+
+func TestValidateDocumentFutureTimestampWithinSkew(t *testing.T) {
+	now := time.Date(2026, 2, 19, 0, 0, 0, 0, time.UTC)
+	doc := minimalValidDoc()
+	doc.Binding.CreatedAt = now.Add(2 * time.Minute).Format(time.RFC3339)
+	doc.BirthCert.BirthTimestamp = doc.Binding.CreatedAt
+
+	result := ValidateDocumentWithOptions(doc, ValidateOptions{Now: func() time.Time { return now }})
+	if !result.Valid {
+		t.Errorf("Expected 2 minutes ahead to be within tolerance, got: %v", result.Errors)
+	}
+	result = ValidateDocumentWithOptions(doc, ValidateOptions{Now: func() time.Time { return now }, ClockSkew: time.Minute})
+	if result.Valid {
+		t.Error("Expected a tighter clock skew to reject 2 minutes ahead")
+	}
+}
+
+func TestValidateDocumentRequiredBirthWitnesses(t *testing.T) {
+	doc := minimalValidDoc() // 3 birth witnesses
+	opts := ValidateOptions{RequiredBirthWitnesses: 5}
+	result := ValidateDocumentWithOptions(doc, opts)
+	if result.Valid {
+		t.Fatal("Expected a society requiring 5 witnesses to reject 3")
+	}
+	if !contains(result.Errors[0], "at least 5 entries, got 3") {
+		t.Errorf("Expected required witness error, got: %v", result.Errors)
+	}
+
+	doc.BirthCert.BirthWitnesses = append(doc.BirthCert.BirthWitnesses, "lct:web4:witness:w4", "lct:web4:witness:w5")
+	if result := ValidateDocumentWithOptions(doc, opts); !result.Valid {
+		t.Errorf("Expected 5 witnesses to satisfy the society, got: %v", result.Errors)
+	}
+}
+
 func TestValidateDocumentMRHNoPaired(t *testing.T) {
 	doc := minimalValidDoc()
 	doc.MRH.Paired = nil
@@ -180,6 +239,95 @@ func TestValidateDocumentNoCitizenPairingWarning(t *testing.T) {
 	}
 }
 
+func TestValidateDocumentCitizenPairingMatchesRole(t *testing.T) {
+	doc := minimalValidDoc()
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Fatalf("Expected matching citizen pairing to be valid, got: %v", result.Errors)
+	}
+
+	doc.MRH.Paired[0].LCTID = "lct:web4:role:citizen:human"
+	result := ValidateDocument(doc)
+	if result.Valid {
+		t.Fatal("Expected invalid for birth_certificate pairing that does not match citizen_role")
+	}
+	if !contains(result.Errors[0], "does not match birth_certificate.citizen_role") {
+		t.Errorf("Expected citizen role mismatch error, got: %v", result.Errors)
+	}
+}
+
+func TestValidateDocumentDuplicateMRHIDs(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Paired = append(doc.MRH.Paired,
+		MRHPaired{LCTID: "lct:web4:service:telemetry", PairingType: PairingOperational, TS: "2026-02-19T00:00:00Z"},
+		MRHPaired{LCTID: "lct:web4:service:telemetry", PairingType: PairingRole, TS: "2026-02-19T00:00:00Z"},
+		MRHPaired{LCTID: "lct:web4:service:telemetry", PairingType: PairingOperational, TS: "2026-02-19T00:00:00Z"},
+	)
+	result := ValidateDocument(doc)
+	if result.Valid {
+		t.Fatal("Expected invalid for duplicate mrh.paired LCT ID")
+	}
+	if len(result.Errors) != 1 || !contains(result.Errors[0], `mrh.paired: "lct:web4:service:telemetry"`) {
+		t.Errorf("Expected a single duplicate error naming the ID, got %v", result.Errors)
+	}
+}
+
+func TestValidateDocumentSameIDAcrossMRHLists(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Bound = []MRHBound{{LCTID: "lct:web4:organization:lab", Type: BoundParent, TS: "2026-02-19T00:00:00Z"}}
+	doc.MRH.Paired = append(doc.MRH.Paired, MRHPaired{
+		LCTID:       "lct:web4:organization:lab",
+		PairingType: PairingOperational,
+		TS:          "2026-02-19T00:00:00Z",
+	})
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected the same ID in bound and paired to be valid, got: %v", result.Errors)
+	}
+}
+
+func TestValidateDocumentWitnessRoles(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:clock", Role: WitnessTime, LastAttestation: "2026-02-19T00:00:00Z"}}
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Fatalf("Expected canonical witness role to be valid, got: %v", result.Errors)
+	}
+
+	// A typed field still accepts any string from JSON
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	data = bytes.Replace(data, []byte(`"role":"time"`), []byte(`"role":"gossip"`), 1)
+	var loaded Document
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	result := ValidateDocument(&loaded)
+	if result.Valid {
+		t.Fatal("Expected non-canonical witness role to be rejected")
+	}
+	if !contains(strings.Join(result.Errors, "\n"), `Invalid mrh.witnessing[0].role: "gossip"`) {
+		t.Errorf("Expected witness role error, got: %v", result.Errors)
+	}
+}
+
+func TestValidateDocumentRequireTensors(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3, doc.V3 = nil, nil
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Fatalf("Expected tensor-less document to be valid by default, got: %v", result.Errors)
+	}
+
+	result := ValidateDocumentWithOptions(doc, ValidateOptions{RequireTensors: true})
+	if result.Valid || !contains(result.Errors[0], "Missing tensors") {
+		t.Errorf("Expected missing tensors error, got: %v", result.Errors)
+	}
+
+	doc.V3 = &V3Tensor{Valuation: 0.2, Veracity: 0.5, Validity: 0.5}
+	if result := ValidateDocumentWithOptions(doc, ValidateOptions{RequireTensors: true}); !result.Valid {
+		t.Errorf("Expected a single tensor to satisfy RequireTensors, got: %v", result.Errors)
+	}
+}
+
 func TestValidateDocumentInvalidT3(t *testing.T) {
 	doc := minimalValidDoc()
 	doc.T3.Talent = 1.5
@@ -212,6 +360,37 @@ func TestValidateDocumentInvalidV3(t *testing.T) {
 	}
 }
 
+func TestValidateDocumentT3SubDimensionOutOfRange(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3.SubDimensions = map[string]map[string]float64{
+		"talent": {"coding": 0.8, "reasoning": 5.0},
+	}
+	result := ValidateDocument(doc)
+	if result.Valid {
+		t.Fatal("Expected invalid for sub-dimension 5.0")
+	}
+	if len(result.Errors) != 1 || !contains(result.Errors[0], "t3_tensor.sub_dimensions.talent.reasoning") {
+		t.Errorf("Expected error naming the sub-dimension path, got: %v", result.Errors)
+	}
+}
+
+func TestValidateDocumentV3ValuationSubDimensionUnbounded(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.V3.SubDimensions = map[string]map[string]float64{
+		"valuation": {"market": 42.0},
+		"veracity":  {"sourcing": 0.9},
+	}
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Fatalf("Expected large valuation sub-dimension to be allowed, got: %v", result.Errors)
+	}
+
+	doc.V3.SubDimensions["veracity"]["sourcing"] = 1.5
+	result := ValidateDocument(doc)
+	if result.Valid || !contains(result.Errors[0], "v3_tensor.sub_dimensions.veracity.sourcing") {
+		t.Errorf("Expected veracity sub-dimension error, got: %v", result.Errors)
+	}
+}
+
 func TestValidateDocumentRevokedWarnings(t *testing.T) {
 	doc := minimalValidDoc()
 	doc.Revocation = &Revocation{Status: RevocationRevoked}
@@ -224,6 +403,102 @@ func TestValidateDocumentRevokedWarnings(t *testing.T) {
 	}
 }
 
+func TestValidateDocumentDuplicateCapabilityWarning(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Policy.Capabilities = []string{"witness:attest", "write:lct", "witness:attest"}
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Expected valid, got: %v", result.Errors)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		if contains(w, "Duplicate capability") && contains(w, "witness:attest") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected duplicate capability warning, got: %v", result.Warnings)
+	}
+}
+
+func TestValidateHardwareAnchorTypes(t *testing.T) {
+	for _, typ := range HardwareAnchorTypes {
+		anchor := "eat:" + typ + ":token_123"
+		if err := ValidateHardwareAnchor(anchor); err != nil {
+			t.Errorf("%s: unexpected error: %v", anchor, err)
+		}
+	}
+}
+
+func TestValidateHardwareAnchorInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing token":  "eat:tpm2:",
+		"no token":       "eat:tpm2",
+		"unknown type":   "eat:quantum:token",
+		"missing prefix": "tpm2:token",
+	}
+	for name, anchor := range tests {
+		if err := ValidateHardwareAnchor(anchor); err == nil {
+			t.Errorf("%s: expected error for %q", name, anchor)
+		}
+	}
+}
+
+func TestValidateDocumentHardwareAnchor(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Binding.HardwareAnchor = "eat:sgx:quote_abc"
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected valid anchor, got: %v", result.Errors)
+	}
+
+	doc.Binding.HardwareAnchor = "eat:unknown:quote_abc"
+	result := ValidateDocument(doc)
+	if result.Valid {
+		t.Fatal("Expected invalid for unknown anchor type")
+	}
+	if !contains(result.Errors[0], "hardware_anchor") {
+		t.Errorf("Expected hardware_anchor error, got: %v", result.Errors)
+	}
+}
+
+func TestDocValidationResultStringInvalid(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Binding.PublicKey = ""
+	doc.MRH.Paired[0].PairingType = PairingOperational
+	result := ValidateDocument(doc)
+
+	report := result.String()
+	if !contains(report, "Errors (1):") || !contains(report, "Missing binding.public_key") {
+		t.Errorf("Expected error section in report:\n%s", report)
+	}
+	if !contains(report, "Warnings (1):") || !contains(report, "birth_certificate pairing") {
+		t.Errorf("Expected warning section in report:\n%s", report)
+	}
+	if !contains(report, "INVALID: 1 error(s), 1 warning(s)") {
+		t.Errorf("Expected INVALID summary in report:\n%s", report)
+	}
+}
+
+func TestDocValidationResultStringValid(t *testing.T) {
+	result := ValidateDocument(minimalValidDoc())
+	report := result.String()
+	if !contains(report, "VALID: 0 error(s)") || contains(report, "INVALID") {
+		t.Errorf("Expected VALID summary with zero errors:\n%s", report)
+	}
+	if contains(report, "Errors (") {
+		t.Errorf("Valid report should have no error section:\n%s", report)
+	}
+
+	var buf bytes.Buffer
+	n, err := result.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != int64(len(report)) || buf.String() != report {
+		t.Errorf("WriteTo should write the String report (%d bytes), wrote %d", len(report), n)
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 // Tensor Operations Tests
 // ═══════════════════════════════════════════════════════════════
@@ -246,6 +521,153 @@ func TestComputeV3Composite(t *testing.T) {
 	}
 }
 
+func TestComputeCompositePersistedWeights(t *testing.T) {
+	t3 := &T3Tensor{Talent: 1.0, Training: 0.5, Temperament: 0.0,
+		Weights: map[string]float64{"talent": 0.2, "training": 0.6, "temperament": 0.2}}
+	if got := ComputeT3Composite(t3); math.Abs(got-0.5) > 0.001 {
+		t.Errorf("T3 composite with custom weights: expected 0.500, got %.3f", got)
+	}
+	v3 := &V3Tensor{Valuation: 1.0, Veracity: 0.0, Validity: 0.0,
+		Weights: map[string]float64{"valuation": 0.5, "veracity": 0.25, "validity": 0.25}}
+	if got := ComputeV3Composite(v3); math.Abs(got-0.5) > 0.001 {
+		t.Errorf("V3 composite with custom weights: expected 0.500, got %.3f", got)
+	}
+
+	doc := minimalValidDoc()
+	doc.T3 = t3
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := ComputeT3Composite(restored.T3); math.Abs(got-0.5) > 0.001 {
+		t.Errorf("Persisted weights not reproduced after round-trip: %.3f", got)
+	}
+}
+
+func TestComputeCompositeDefaultWeights(t *testing.T) {
+	t3 := &T3Tensor{Talent: 1.0, Training: 0.0, Temperament: 0.0}
+	if got := ComputeT3Composite(t3); math.Abs(got-DefaultT3Weights()["talent"]) > 0.001 {
+		t.Errorf("Expected default talent weight, got %.3f", got)
+	}
+	if t3.Weights != nil {
+		t.Error("Computing with defaults should not modify the tensor")
+	}
+
+	built := NewBuilder(EntityAI, "weights").WithT3(1, 0, 0).BuildUnsafe()
+	if !reflect.DeepEqual(built.T3.Weights, DefaultT3Weights()) {
+		t.Errorf("Expected builder to record default weights, got %v", built.T3.Weights)
+	}
+
+	built.T3.Weights["talent"] = 1
+	DefaultV3Weights()["valuation"] = 1
+	if DefaultT3Weights()["talent"] != 0.4 || DefaultV3Weights()["valuation"] != 0.3 {
+		t.Error("Mutating recorded or returned weights should not change the defaults")
+	}
+}
+
+func TestValidateDocumentWeightsMustSumToOne(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3.Weights = map[string]float64{"talent": 0.5, "training": 0.5, "temperament": 0.5}
+	result := ValidateDocument(doc)
+	if result.Valid || !contains(result.Errors[0], "t3_tensor.weights must sum to 1.0") {
+		t.Errorf("Expected weight sum error, got: %v", result.Errors)
+	}
+
+	doc.T3.Weights = map[string]float64{"talent": 0.3334, "training": 0.3333, "temperament": 0.3333}
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected weights summing to ~1 to be valid, got: %v", result.Errors)
+	}
+}
+
+func TestT3Blend(t *testing.T) {
+	obs := T3Tensor{Talent: 1.0, Training: 0.9, Temperament: 0.1}
+	tests := []struct {
+		alpha                         float64
+		talent, training, temperament float64
+	}{
+		{0, 0.5, 0.5, 0.5},
+		{1, 1.0, 0.9, 0.1},
+		{0.5, 0.75, 0.7, 0.3},
+	}
+	for _, tt := range tests {
+		t3 := DefaultT3()
+		if err := t3.Blend(obs, tt.alpha); err != nil {
+			t.Fatalf("Blend(%g) failed: %v", tt.alpha, err)
+		}
+		if math.Abs(t3.Talent-tt.talent) > 0.001 || math.Abs(t3.Training-tt.training) > 0.001 || math.Abs(t3.Temperament-tt.temperament) > 0.001 {
+			t.Errorf("Blend(%g): unexpected dimensions %+v", tt.alpha, t3)
+		}
+		if math.Abs(t3.CompositeScore-ComputeT3Composite(&t3)) > 0.001 {
+			t.Errorf("Blend(%g): composite not recomputed", tt.alpha)
+		}
+	}
+}
+
+func TestTensorRoundingRemovesFloatDrift(t *testing.T) {
+	a, b := 0.1, 0.2 // variables, so the sum is not folded exactly at compile time
+	t3 := T3Tensor{Talent: a + b, Training: 0.7, Temperament: 0.7}
+	if t3.Talent == 0.3 {
+		t.Fatal("Expected 0.1+0.2 to drift before rounding")
+	}
+	if got := ComputeT3Composite(&t3); got != 0.54 {
+		t.Errorf("Expected composite 0.54, got %v", got)
+	}
+
+	doc := minimalValidDoc()
+	doc.T3 = &t3
+	doc.V3.Veracity = a + 0.7
+	doc.V3.SubDimensions = map[string]map[string]float64{"veracity": {"sourcing": a + b}}
+	doc.RoundTensors()
+	if doc.T3.Talent != 0.3 || doc.V3.Veracity != 0.8 || doc.V3.SubDimensions["veracity"]["sourcing"] != 0.3 {
+		t.Errorf("Expected drift rounded away, got t3=%+v v3=%+v", doc.T3, doc.V3)
+	}
+
+	before, err := doc.CanonicalBytes()
+	if err != nil {
+		t.Fatalf("CanonicalBytes failed: %v", err)
+	}
+	doc.RoundTensors()
+	after, _ := doc.CanonicalBytes()
+	if string(before) != string(after) {
+		t.Error("Expected RoundTensors to be idempotent")
+	}
+}
+
+func TestRoundTensorsTo(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = &T3Tensor{Talent: 0.123456789, Training: 0.5, Temperament: 0.5}
+	if err := doc.RoundTensorsTo(2); err != nil {
+		t.Fatalf("RoundTensorsTo failed: %v", err)
+	}
+	if doc.T3.Talent != 0.12 {
+		t.Errorf("Expected talent 0.12, got %v", doc.T3.Talent)
+	}
+	for _, places := range []int{-1, 16, 400} {
+		if err := doc.RoundTensorsTo(places); err == nil {
+			t.Errorf("Expected RoundTensorsTo(%d) to be rejected", places)
+		}
+	}
+	if math.IsNaN(doc.T3.Talent) || doc.T3.Talent != 0.12 {
+		t.Errorf("Rejected precision should leave tensors untouched, got %v", doc.T3.Talent)
+	}
+}
+
+func TestT3BlendInvalidAlpha(t *testing.T) {
+	t3 := DefaultT3()
+	for _, alpha := range []float64{-0.1, 1.5} {
+		if err := t3.Blend(T3Tensor{}, alpha); err == nil {
+			t.Errorf("Expected error for alpha %g", alpha)
+		}
+	}
+	if t3.Talent != 0.5 {
+		t.Error("Rejected blend should not modify the tensor")
+	}
+}
+
 func TestDefaultT3(t *testing.T) {
 	t3 := DefaultT3()
 	if t3.Talent != 0.5 || t3.Training != 0.5 || t3.Temperament != 0.5 {
@@ -333,6 +755,105 @@ func TestDocumentJSONRoundtrip(t *testing.T) {
 	}
 }
 
+func TestDocumentJSONPreservesUnknownFields(t *testing.T) {
+	data, err := json.Marshal(minimalValidDoc())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	future := strings.Replace(string(data), "{", `{"quantum_binding":{"scheme":"ml-kem","key":"qk1"},`, 1)
+
+	var doc Document
+	if err := json.Unmarshal([]byte(future), &doc); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	assertEqual(t, "extra", `{"scheme":"ml-kem","key":"qk1"}`, string(doc.Extra["quantum_binding"]))
+	if len(doc.Extra) != 1 {
+		t.Errorf("Expected only the unknown field in Extra, got %v", doc.Extra)
+	}
+
+	out, err := json.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var restored Document
+	if err := json.Unmarshal(out, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	// Extra values are preserved, re-emitted in canonical key order
+	assertEqual(t, "round-trip", `{"key":"qk1","scheme":"ml-kem"}`, string(restored.Extra["quantum_binding"]))
+	assertEqual(t, "lct_id", doc.LCTID, restored.LCTID)
+}
+
+func TestDocumentMarshalJSONSortedKeys(t *testing.T) {
+	data, err := json.Marshal(minimalValidDoc())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// Re-encoding through generic maps sorts keys at every level, so
+	// canonical output must come back byte-identical
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	sorted, _ := json.Marshal(tree)
+	assertEqual(t, "compact", string(sorted), string(data))
+}
+
+func TestDocumentMarshalJSONIndent(t *testing.T) {
+	doc := minimalValidDoc()
+	data, err := doc.MarshalJSONIndent()
+	if err != nil {
+		t.Fatalf("MarshalJSONIndent failed: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "{\n  \"") {
+		t.Errorf("Expected two-space indentation, got %.40q", data)
+	}
+
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.Hash() != doc.Hash() {
+		t.Error("Indented form should unmarshal back to an equal document")
+	}
+
+	compact, _ := json.Marshal(doc)
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	assertEqual(t, "compacted indent", string(compact), buf.String())
+}
+
+func TestTensorZeroCompositeSerialized(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = &T3Tensor{}
+	doc.V3 = &V3Tensor{}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var raw map[string]map[string]interface{}
+	json.Unmarshal(data, &raw)
+	for _, key := range []string{"t3_tensor", "v3_tensor"} {
+		if v, ok := raw[key]["composite_score"]; !ok || v != 0.0 {
+			t.Errorf("%s: expected composite_score 0 to be present, got %v (present=%v)", key, v, ok)
+		}
+		if v, ok := raw[key]["valuation"]; key == "v3_tensor" && (!ok || v != 0.0) {
+			t.Errorf("v3_tensor: expected valuation 0 to be present, got %v", v)
+		}
+	}
+
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.T3 == nil || restored.T3.CompositeScore != 0 || restored.V3 == nil || restored.V3.CompositeScore != 0 {
+		t.Errorf("Zero composites should survive round-trip: %+v %+v", restored.T3, restored.V3)
+	}
+}
+
 func TestDocumentHash(t *testing.T) {
 	doc := minimalValidDoc()
 	hash1 := doc.Hash()
@@ -354,6 +875,28 @@ func TestDocumentHash(t *testing.T) {
 	}
 }
 
+func TestHashCompositeIncremental(t *testing.T) {
+	doc := minimalValidDoc()
+	base := doc.HashExcludingAttestations()
+	composite := doc.HashComposite()
+
+	doc.Attestations = append(doc.Attestations, Attestation{
+		Witness: "lct:web4:witness:w1", Type: "existence", Sig: "cose:sig", TS: "2026-02-19T00:00:00Z",
+	})
+	if doc.HashExcludingAttestations() != base {
+		t.Error("Adding an attestation should not change the base hash")
+	}
+	if doc.HashComposite() == composite {
+		t.Error("Adding an attestation should change the composite hash")
+	}
+	assertEqual(t, "cached base", doc.HashComposite(), CompositeHash(base, AttestationsHash(doc.Attestations)))
+
+	doc.LCTID = "lct:web4:ai:different"
+	if doc.HashExcludingAttestations() == base {
+		t.Error("Changing a base field should change the base hash")
+	}
+}
+
 func TestDocumentToURI(t *testing.T) {
 	doc := minimalValidDoc()
 	uri := doc.ToURI("testnet", "agent")
@@ -367,6 +910,244 @@ func TestDocumentToURI(t *testing.T) {
 	}
 }
 
+func TestDocumentToURIRoundTripAllEntityTypes(t *testing.T) {
+	for _, et := range ValidEntityTypes {
+		doc := NewBuilder(et, "roundtrip").BuildUnsafe()
+		uri := doc.ToURI("testnet", "agent")
+		result := ParseURI(uri)
+		if !result.Success {
+			t.Errorf("%s: ToURI produced unparseable %s: %v", et, uri, result.Errors)
+			continue
+		}
+		assertEqual(t, "component", string(et), result.Identity.Component)
+	}
+}
+
+func TestDocumentToURISanitizesLCTID(t *testing.T) {
+	for lctID, instance := range map[string]string{
+		"lct:web4:ai:abc123:":      "abc123",
+		"lct:web4:ai:":             "ai",
+		"nocolons":                 "nocolons",
+		"lct:web4:ai:_odd.id/with": "odd-id-with",
+		"":                         "unknown",
+	} {
+		doc := minimalValidDoc()
+		doc.LCTID = lctID
+		doc.Binding.EntityType = "AI"
+		result := ParseURI(doc.ToURI("", ""))
+		if !result.Success {
+			t.Errorf("LCT ID %q: ToURI produced unparseable URI: %v", lctID, result.Errors)
+			continue
+		}
+		assertEqual(t, "instance", instance, result.Identity.Instance)
+		assertEqual(t, "component", "ai", result.Identity.Component)
+	}
+}
+
+func TestDocumentCloneIsDeep(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Policy.Constraints = map[string]interface{}{"allowed_networks": []string{"testnet"}}
+	doc.T3.SubDimensions = map[string]map[string]float64{"talent": {"coding": 0.8}}
+	doc.Attestations = []Attestation{{Witness: "lct:web4:witness:w1", Claims: map[string]interface{}{"k": "v"}}}
+
+	c := doc.Clone()
+	if c.Hash() != doc.Hash() {
+		t.Fatal("Clone should hash equal to original")
+	}
+
+	c.Policy.Capabilities[0] = "changed"
+	c.Policy.Constraints["allowed_networks"].([]string)[0] = "mainnet"
+	c.T3.SubDimensions["talent"]["coding"] = 0.1
+	c.Attestations[0].Claims["k"] = "changed"
+	c.MRH.Paired[0].LCTID = "changed"
+	c.BirthCert.BirthWitnesses[0] = "changed"
+	c.Revocation.Status = RevocationRevoked
+
+	if doc.Policy.Capabilities[0] != "witness:attest" ||
+		doc.Policy.Constraints["allowed_networks"].([]string)[0] != "testnet" ||
+		doc.T3.SubDimensions["talent"]["coding"] != 0.8 ||
+		doc.Attestations[0].Claims["k"] != "v" ||
+		doc.MRH.Paired[0].LCTID != "lct:web4:role:citizen:ai" ||
+		doc.BirthCert.BirthWitnesses[0] != "lct:web4:witness:w1" ||
+		doc.Revocation.Status != RevocationActive {
+		t.Error("Mutating the clone affected the original")
+	}
+}
+
+func TestEqualsIgnoringTimestamps(t *testing.T) {
+	doc1 := minimalValidDoc()
+	doc2 := minimalValidDoc()
+
+	later := "2026-02-19T00:00:01Z"
+	doc2.Binding.CreatedAt = later
+	doc2.BirthCert.BirthTimestamp = later
+	doc2.MRH.LastUpdated = later
+	doc2.MRH.Paired[0].TS = later
+	doc2.T3.LastComputed = later
+	doc2.Revocation.TS = later
+
+	if doc1.Hash() == doc2.Hash() {
+		t.Fatal("Precondition: timestamp changes should alter the hash")
+	}
+	if !doc1.EqualsIgnoringTimestamps(doc2) {
+		t.Error("Documents differing only in timestamps should be equal")
+	}
+	if doc2.Binding.CreatedAt != later {
+		t.Error("EqualsIgnoringTimestamps should not mutate its operands")
+	}
+}
+
+func TestEqualsIgnoringTimestampsBuiltApart(t *testing.T) {
+	clock := time.Date(2026, 2, 19, 12, 0, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { Now = orig }(Now)
+	Now = func() time.Time { return clock }
+
+	build := func() *Document {
+		return NewBuilder(EntityAI, "agent").
+			WithBinding("mb64key", "cose:proof").
+			WithT3(0.8, 0.7, 0.9).
+			AddCapability("witness:attest").
+			AddLineage(LineageGenesis, "").
+			BuildUnsafe()
+	}
+	doc1 := build()
+	clock = clock.Add(1100 * time.Millisecond)
+	doc2 := build()
+	if doc1.Binding.CreatedAt == doc2.Binding.CreatedAt {
+		t.Fatal("Expected documents built apart to carry different timestamps")
+	}
+
+	// LCT IDs and subjects are derived from build time; align them
+	doc2.LCTID, doc2.Subject = doc1.LCTID, doc1.Subject
+
+	if !doc1.EqualsIgnoringTimestamps(doc2) {
+		t.Error("Documents built a second apart should be equal ignoring timestamps")
+	}
+
+	doc2.Policy.Capabilities = append(doc2.Policy.Capabilities, "write:lct")
+	if doc1.EqualsIgnoringTimestamps(doc2) {
+		t.Error("Documents differing in a capability should not be equal")
+	}
+}
+
+func TestIdentityFromDocument(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = &T3Tensor{Talent: 0.8, Training: 0.7, Temperament: 0.9} // composite 0.8
+	doc.Policy.Capabilities = []string{"read:lct", "witness:attest"}
+
+	id := IdentityFromDocument(doc, "testnet", "agent")
+	if id.TrustThreshold != 0.8 {
+		t.Errorf("Expected derived threshold 0.8, got %v", id.TrustThreshold)
+	}
+	if len(id.Capabilities) != 2 || id.Capabilities[0] != "read:lct" || id.Capabilities[1] != "witness:attest" {
+		t.Errorf("Expected policy capabilities, got %v", id.Capabilities)
+	}
+	assertEqual(t, "publicKeyHash", doc.Subject, id.PublicKeyHash)
+
+	uri := BuildURI(id)
+	result := ParseURI(uri)
+	if !result.Success {
+		t.Fatalf("Derived URI does not parse: %s: %v", uri, result.Errors)
+	}
+	if result.Identity.TrustThreshold != 0.8 || len(result.Identity.Capabilities) != 2 {
+		t.Errorf("Derived URI lost fields: %s", uri)
+	}
+
+	doc.T3 = nil
+	if id := IdentityFromDocument(doc, "", ""); id.TrustThreshold != -1 {
+		t.Errorf("Expected unset threshold without T3, got %v", id.TrustThreshold)
+	}
+}
+
+func TestRotateBinding(t *testing.T) {
+	doc := minimalValidDoc()
+	rotated, err := RotateBinding(doc, "mb64newkey", "cose:new_proof", "cose:continuity")
+	if err != nil {
+		t.Fatalf("RotateBinding failed: %v", err)
+	}
+
+	if rotated.Binding.PublicKey != "mb64newkey" || rotated.Binding.BindingProof != "cose:new_proof" {
+		t.Errorf("Binding not updated: %+v", rotated.Binding)
+	}
+	if doc.Binding.PublicKey != "mb64testkey" {
+		t.Error("Original document should be unchanged")
+	}
+
+	last := rotated.Lineage[len(rotated.Lineage)-1]
+	if last.Reason != LineageRotation || last.Parent != "mb64testkey" {
+		t.Errorf("Expected rotation lineage referencing the previous key, got %+v", last)
+	}
+	att := rotated.Attestations[len(rotated.Attestations)-1]
+	if att.Type != AttestationBindingRotation || att.Sig != "cose:continuity" || att.Claims["previous_public_key"] != "mb64testkey" {
+		t.Errorf("Expected continuity attestation, got %+v", att)
+	}
+	if result := ValidateDocument(rotated); !result.Valid {
+		t.Errorf("Rotated document invalid: %v", result.Errors)
+	}
+}
+
+func TestRotateBindingRevoked(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Revocation = &Revocation{Status: RevocationRevoked, Reason: RevocationCompromise}
+	if _, err := RotateBinding(doc, "mb64newkey", "cose:new_proof", "cose:continuity"); err == nil {
+		t.Error("Expected error rotating a revoked document")
+	}
+}
+
+func TestVerifyAllBindingProofs(t *testing.T) {
+	doc, err := NewBuilder(EntityAI, "multisig").
+		WithBinding("mb64key", "cose:primary").
+		WithBirthCertificate(
+			"lct:web4:society:main",
+			"lct:web4:role:citizen:ai",
+			BirthPlatform,
+			[]string{"lct:web4:witness:w1", "lct:web4:witness:w2", "lct:web4:witness:w3"},
+		).
+		AddBindingProof("cose:authority_a").
+		AddBindingProof("cose:authority_b").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	var checked []string
+	errs := doc.VerifyAllBindingProofs(func(proof string) error {
+		checked = append(checked, proof)
+		return nil
+	})
+	if len(errs) != 0 {
+		t.Errorf("Expected all proofs to verify, got %v", errs)
+	}
+	assertEqual(t, "checked", "cose:primary,cose:authority_a,cose:authority_b", strings.Join(checked, ","))
+
+	errs = doc.VerifyAllBindingProofs(func(proof string) error {
+		if proof == "cose:authority_b" {
+			return fmt.Errorf("bad signature")
+		}
+		return nil
+	})
+	if len(errs) != 1 || !contains(errs[0].Error(), "additional_proofs[1]: bad signature") {
+		t.Errorf("Expected one failure for the second additional proof, got %v", errs)
+	}
+
+	rotated, err := RotateBinding(doc, "mb64newkey", "cose:new_proof", "cose:continuity")
+	if err != nil {
+		t.Fatalf("RotateBinding failed: %v", err)
+	}
+	if len(rotated.Binding.AdditionalProofs) != 0 || len(doc.Binding.AdditionalProofs) != 2 {
+		t.Error("Rotation should drop additional proofs from the copy only")
+	}
+}
+
+func TestValidateDocumentEmptyAdditionalProof(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Binding.AdditionalProofs = []string{"cose:a", ""}
+	result := ValidateDocument(doc)
+	if result.Valid || !contains(result.Errors[0], "additional_proofs[1]") {
+		t.Errorf("Expected empty additional proof error, got %v", result.Errors)
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 // Entity Type Tests
 // ═══════════════════════════════════════════════════════════════
@@ -391,6 +1172,34 @@ func TestInvalidEntityType(t *testing.T) {
 	}
 }
 
+func TestNormalizeEntityType(t *testing.T) {
+	for in, want := range map[string]EntityType{"AI": EntityAI, "Human": EntityHuman, " society ": EntitySociety} {
+		if got, ok := NormalizeEntityType(in); !ok || got != want {
+			t.Errorf("NormalizeEntityType(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if got, ok := NormalizeEntityType("martian"); ok {
+		t.Errorf("Expected 'martian' to be rejected, got %q", got)
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Binding.EntityType = "AI"
+	if ValidateDocument(doc).Valid {
+		t.Fatal("Strict validation should reject non-canonical casing")
+	}
+	NormalizeDocument(doc)
+	assertEqual(t, "entity_type", "ai", string(doc.Binding.EntityType))
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected normalized document to validate, got %v", result.Errors)
+	}
+
+	doc.Binding.EntityType = "Martian"
+	NormalizeDocument(doc)
+	assertEqual(t, "unknown entity_type", "Martian", string(doc.Binding.EntityType))
+}
+
 // ═══════════════════════════════════════════════════════════════
 // Helpers
 // ═══════════════════════════════════════════════════════════════
@@ -407,3 +1216,25 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// ═══════════════════════════════════════════════════════════════
+// Benchmarks
+// ═══════════════════════════════════════════════════════════════
+
+func BenchmarkValidateDocument(b *testing.B) {
+	doc := minimalValidDoc()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateDocument(doc)
+	}
+}
+
+func BenchmarkValidateDocumentInvalid(b *testing.B) {
+	doc := minimalValidDoc()
+	doc.Binding.EntityType = "alien"
+	doc.T3.Talent = 2
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateDocument(doc)
+	}
+}
@@ -0,0 +1,608 @@
+package lct
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// requireEntityType returns an error unless doc's binding has entity type et.
+func requireEntityType(doc *Document, et EntityType) error {
+	if doc == nil {
+		return fmt.Errorf("nil document")
+	}
+	if doc.Binding.EntityType != et {
+		return fmt.Errorf("document %s is entity type %q, expected %q", doc.LCTID, doc.Binding.EntityType, et)
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Accumulator Entities
+// ═══════════════════════════════════════════════════════════════
+
+// AttestationAccumulatorContribution is the attestation type recorded for
+// each value contribution added to an accumulator.
+const AttestationAccumulatorContribution = "accumulator:contribution"
+
+// Accumulator sums V3 value contributions into an accumulator-type LCT.
+// Each contribution is recorded as an attestation on Doc.
+type Accumulator struct {
+	Doc   *Document
+	Total float64
+
+	count       int
+	veracitySum float64
+	validitySum float64
+}
+
+// NewAccumulator wraps an accumulator-type document.
+func NewAccumulator(doc *Document) (*Accumulator, error) {
+	if err := requireEntityType(doc, EntityAccumulator); err != nil {
+		return nil, err
+	}
+	return &Accumulator{Doc: doc}, nil
+}
+
+// Add adds v3.Valuation to the running total and records the contribution
+// as an unsigned attestation. The contribution is attributed to its first
+// computation witness, or to the accumulator itself if none is named.
+func (a *Accumulator) Add(v3 V3Tensor) error {
+	return a.add(v3, "")
+}
+
+// AddSigned is Add with the contribution attestation built by
+// NewAttestation and signed with sig, which must not be empty.
+func (a *Accumulator) AddSigned(v3 V3Tensor, sig string) error {
+	if sig == "" {
+		return fmt.Errorf("signed contribution requires a signature")
+	}
+	return a.add(v3, sig)
+}
+
+func (a *Accumulator) add(v3 V3Tensor, sig string) error {
+	if err := requireEntityType(a.Doc, EntityAccumulator); err != nil {
+		return err
+	}
+	if v3.Valuation < 0 {
+		return fmt.Errorf("contribution valuation must be >= 0, got %g", v3.Valuation)
+	}
+
+	witness := a.Doc.LCTID
+	if len(v3.ComputationWitnesses) > 0 {
+		witness = v3.ComputationWitnesses[0]
+	}
+	claims := map[string]interface{}{
+		"valuation": v3.Valuation,
+		"total":     a.Total + v3.Valuation,
+	}
+	att := Attestation{
+		Witness: witness,
+		Type:    AttestationAccumulatorContribution,
+		TS:      Now().UTC().Format(time.RFC3339),
+		Claims:  claims,
+	}
+	if sig != "" {
+		var err error
+		if att, err = NewAttestation(witness, AttestationAccumulatorContribution, sig, claims); err != nil {
+			return err
+		}
+	}
+
+	a.Total += v3.Valuation
+	a.count++
+	a.veracitySum += v3.Veracity
+	a.validitySum += v3.Validity
+	a.Doc.Attestations = append(a.Doc.Attestations, att)
+	return nil
+}
+
+// Snapshot returns the accumulated value as a V3 tensor: valuation is the
+// running total, veracity and validity are contribution averages.
+func (a *Accumulator) Snapshot() V3Tensor {
+	v3 := V3Tensor{Valuation: a.Total}
+	if a.count > 0 {
+		v3.Veracity = a.veracitySum / float64(a.count)
+		v3.Validity = a.validitySum / float64(a.count)
+	}
+	v3.CompositeScore = ComputeV3Composite(&v3)
+	v3.LastComputed = Now().UTC().Format(time.RFC3339)
+	return v3
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Dictionary Entities
+// ═══════════════════════════════════════════════════════════════
+
+// ConstraintTerms is the Policy.Constraints key holding a dictionary's
+// term → LCT ID map.
+const ConstraintTerms = "terms"
+
+// LookupTerm resolves term to its canonical LCT ID in a dictionary-type
+// document. It returns false for unknown terms and non-dictionary documents.
+func (doc *Document) LookupTerm(term string) (string, bool) {
+	if doc.Binding.EntityType != EntityDictionary {
+		return "", false
+	}
+	switch terms := doc.Policy.Constraints[ConstraintTerms].(type) {
+	case map[string]interface{}:
+		id, ok := terms[term].(string)
+		return id, ok
+	case map[string]string:
+		id, ok := terms[term]
+		return id, ok
+	}
+	return "", false
+}
+
+// AddTerm maps term to lctID in a dictionary-type document, replacing any
+// existing mapping.
+func (doc *Document) AddTerm(term, lctID string) error {
+	if err := requireEntityType(doc, EntityDictionary); err != nil {
+		return err
+	}
+	if term == "" {
+		return fmt.Errorf("empty dictionary term")
+	}
+	if !anyLCTIDPattern.MatchString(lctID) {
+		return fmt.Errorf("invalid lct_id for term %q: %q", term, lctID)
+	}
+
+	if doc.Policy.Constraints == nil {
+		doc.Policy.Constraints = map[string]interface{}{}
+	}
+	switch terms := doc.Policy.Constraints[ConstraintTerms].(type) {
+	case map[string]interface{}:
+		terms[term] = lctID
+	case map[string]string:
+		terms[term] = lctID
+	case nil:
+		doc.Policy.Constraints[ConstraintTerms] = map[string]interface{}{term: lctID}
+	default:
+		return fmt.Errorf("policy.constraints.%s is %T, expected a map", ConstraintTerms, terms)
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Oracle Entities
+// ═══════════════════════════════════════════════════════════════
+
+// AttestationOracleClaim is the attestation type for oracle claims.
+const AttestationOracleClaim = "oracle:claim"
+
+// OracleClaim is an external fact asserted by an oracle as a
+// subject–predicate–object triple.
+type OracleClaim struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	Confidence float64 // 0.0-1.0
+	Source     string  // external origin of the fact (URL, feed ID)
+}
+
+func (c OracleClaim) validate() error {
+	if c.Subject == "" || c.Predicate == "" {
+		return fmt.Errorf("oracle claim requires subject and predicate")
+	}
+	if c.Confidence < 0 || c.Confidence > 1 {
+		return fmt.Errorf("oracle claim confidence must be 0.0-1.0, got %g", c.Confidence)
+	}
+	return nil
+}
+
+// AttestOracleClaim appends an oracle:claim attestation witnessed by the
+// oracle document itself, encoding c in the attestation claims.
+func (doc *Document) AttestOracleClaim(c OracleClaim, sig string) error {
+	if err := requireEntityType(doc, EntityOracle); err != nil {
+		return err
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if sig == "" {
+		return fmt.Errorf("oracle claim requires a signature")
+	}
+	att, err := NewAttestation(doc.LCTID, AttestationOracleClaim, sig, map[string]interface{}{
+		"subject":    c.Subject,
+		"predicate":  c.Predicate,
+		"object":     c.Object,
+		"confidence": c.Confidence,
+		"source":     c.Source,
+	})
+	if err != nil {
+		return err
+	}
+	doc.Attestations = append(doc.Attestations, att)
+	return nil
+}
+
+// ParseOracleClaims decodes every oracle:claim attestation on doc.
+func ParseOracleClaims(doc *Document) ([]OracleClaim, error) {
+	var claims []OracleClaim
+	for i, a := range doc.Attestations {
+		if a.Type != AttestationOracleClaim {
+			continue
+		}
+		str := func(key string) string {
+			s, _ := a.Claims[key].(string)
+			return s
+		}
+		confidence, ok := toFloat(a.Claims["confidence"])
+		if !ok {
+			return nil, fmt.Errorf("attestations[%d]: oracle claim confidence missing or non-numeric", i)
+		}
+		c := OracleClaim{
+			Subject:    str("subject"),
+			Predicate:  str("predicate"),
+			Object:     str("object"),
+			Confidence: confidence,
+			Source:     str("source"),
+		}
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("attestations[%d]: %v", i, err)
+		}
+		claims = append(claims, c)
+	}
+	return claims, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Role Entities
+// ═══════════════════════════════════════════════════════════════
+
+// EffectiveCapabilities returns the union of the citizen's own capabilities
+// and those granted by every role LCT it is paired to via PairingRole,
+// deduplicated and sorted. Role documents are fetched through resolver.
+func EffectiveCapabilities(citizen *Document, resolver Resolver) ([]string, error) {
+	return EffectiveCapabilitiesContext(context.Background(), citizen, resolver)
+}
+
+// EffectiveCapabilitiesContext is EffectiveCapabilities with ctx passed to
+// every role lookup.
+func EffectiveCapabilitiesContext(ctx context.Context, citizen *Document, resolver Resolver) ([]string, error) {
+	set := map[string]bool{}
+	for _, c := range citizen.Policy.Capabilities {
+		set[c] = true
+	}
+	for _, p := range citizen.MRH.Paired {
+		if p.PairingType != PairingRole {
+			continue
+		}
+		role, err := resolver.Resolve(ctx, p.LCTID)
+		if err != nil {
+			return nil, fmt.Errorf("resolving role %s: %w", p.LCTID, err)
+		}
+		if err := requireEntityType(role, EntityRole); err != nil {
+			return nil, err
+		}
+		for _, c := range role.Policy.Capabilities {
+			set[c] = true
+		}
+	}
+
+	caps := make([]string, 0, len(set))
+	for c := range set {
+		caps = append(caps, c)
+	}
+	sort.Strings(caps)
+	return caps, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Society Entities
+// ═══════════════════════════════════════════════════════════════
+
+// VerifyMembership checks that citizen was issued by society: the
+// citizen's birth certificate names the society's LCT ID, the society is a
+// society entity, and the society has not been revoked.
+func VerifyMembership(citizen, society *Document) error {
+	if citizen == nil {
+		return fmt.Errorf("nil citizen document")
+	}
+	if err := requireEntityType(society, EntitySociety); err != nil {
+		return err
+	}
+	if citizen.BirthCert.IssuingSociety != society.LCTID {
+		return fmt.Errorf("citizen %s was issued by %q, not %s", citizen.LCTID, citizen.BirthCert.IssuingSociety, society.LCTID)
+	}
+	if society.Revocation != nil && society.Revocation.Status == RevocationRevoked {
+		return fmt.Errorf("society %s is revoked", society.LCTID)
+	}
+	return nil
+}
+
+// ValidateSociety validates every citizen of society and cross-checks the
+// set, returning one result per citizen in order. On top of each
+// citizen's own validation it reports failed membership (see
+// VerifyMembership), birth witnesses that are in the set but not members,
+// and subject DIDs shared with an earlier citizen.
+func ValidateSociety(society *Document, citizens []*Document) []DocValidationResult {
+	byID := make(map[string]*Document, len(citizens))
+	for _, c := range citizens {
+		if c != nil {
+			byID[c.LCTID] = c
+		}
+	}
+
+	results := make([]DocValidationResult, len(citizens))
+	subjects := make(map[string]string, len(citizens))
+	for i, c := range citizens {
+		if c == nil {
+			results[i] = DocValidationResult{Valid: false, Errors: []string{"Citizen document is nil"}}
+			continue
+		}
+		r := ValidateDocument(c)
+		if err := VerifyMembership(c, society); err != nil {
+			r.Errors = append(r.Errors, fmt.Sprintf("Society membership: %v", err))
+		}
+		for _, w := range c.BirthCert.BirthWitnesses {
+			if peer, ok := byID[w]; ok && society != nil && peer.BirthCert.IssuingSociety != society.LCTID {
+				r.Errors = append(r.Errors, fmt.Sprintf("Birth witness %q is not a member of society %s", w, society.LCTID))
+			}
+		}
+		if first, ok := subjects[c.Subject]; ok {
+			r.Errors = append(r.Errors, fmt.Sprintf("Subject %q is shared with citizen %s", c.Subject, first))
+		} else {
+			subjects[c.Subject] = c.LCTID
+		}
+		r.Valid = len(r.Errors) == 0
+		results[i] = r
+	}
+	return results
+}
+
+// ConstraintNamespace is the Policy.Constraints key holding a society's
+// short name → LCT ID map.
+const ConstraintNamespace = "namespace"
+
+// ResolveShortRef expands shortName to the full LCT ID registered in the
+// society's namespace (see RegisterShortRef). It returns an error for
+// non-society documents and for names that are not registered.
+func ResolveShortRef(society *Document, shortName string) (string, error) {
+	if err := requireEntityType(society, EntitySociety); err != nil {
+		return "", err
+	}
+	var id string
+	switch ns := society.Policy.Constraints[ConstraintNamespace].(type) {
+	case map[string]interface{}:
+		id, _ = ns[shortName].(string)
+	case map[string]string:
+		id = ns[shortName]
+	}
+	if id == "" {
+		return "", fmt.Errorf("unknown short reference %q in society %s", shortName, society.LCTID)
+	}
+	return id, nil
+}
+
+// RegisterShortRef maps name to lctID in a society-type document's
+// namespace, replacing any existing mapping.
+func (doc *Document) RegisterShortRef(name, lctID string) error {
+	if err := requireEntityType(doc, EntitySociety); err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("empty short reference name")
+	}
+	if !anyLCTIDPattern.MatchString(lctID) {
+		return fmt.Errorf("invalid lct_id for short reference %q: %q", name, lctID)
+	}
+
+	if doc.Policy.Constraints == nil {
+		doc.Policy.Constraints = map[string]interface{}{}
+	}
+	switch ns := doc.Policy.Constraints[ConstraintNamespace].(type) {
+	case map[string]interface{}:
+		ns[name] = lctID
+	case map[string]string:
+		ns[name] = lctID
+	case nil:
+		doc.Policy.Constraints[ConstraintNamespace] = map[string]interface{}{name: lctID}
+	default:
+		return fmt.Errorf("policy.constraints.%s is %T, expected a map", ConstraintNamespace, ns)
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Task Entities
+// ═══════════════════════════════════════════════════════════════
+
+// ConstraintTaskStatus is the Policy.Constraints key holding a task's
+// lifecycle status.
+const ConstraintTaskStatus = "status"
+
+// Task lifecycle statuses.
+const (
+	TaskPending   = "pending"
+	TaskActive    = "active"
+	TaskCompleted = "completed"
+)
+
+// AttestationTaskCompleted is the attestation type recorded by CompleteTask.
+const AttestationTaskCompleted = "task:completed"
+
+// TaskStatus returns a task document's lifecycle status. It returns false
+// for non-task documents and tasks without a status.
+func (doc *Document) TaskStatus() (string, bool) {
+	if doc.Binding.EntityType != EntityTask {
+		return "", false
+	}
+	status, ok := doc.Policy.Constraints[ConstraintTaskStatus].(string)
+	return status, ok && status != ""
+}
+
+// CompleteTask marks a task completed, records outcome as the task's V3
+// tensor, and records the completing witness as an action witness with a
+// task:completed attestation. Completing an already-completed task fails.
+func (doc *Document) CompleteTask(outcome V3Tensor, witness string, sig string) error {
+	if err := requireEntityType(doc, EntityTask); err != nil {
+		return err
+	}
+	if status, _ := doc.TaskStatus(); status == TaskCompleted {
+		return fmt.Errorf("task %s is already completed", doc.LCTID)
+	}
+	if witness == "" || sig == "" {
+		return fmt.Errorf("task completion requires witness and signature")
+	}
+	att, err := NewAttestation(witness, AttestationTaskCompleted, sig, map[string]interface{}{"status": TaskCompleted})
+	if err != nil {
+		return err
+	}
+
+	now := att.TS
+	if doc.Policy.Constraints == nil {
+		doc.Policy.Constraints = map[string]interface{}{}
+	}
+	doc.Policy.Constraints[ConstraintTaskStatus] = TaskCompleted
+
+	if outcome.CompositeScore == 0 {
+		outcome.CompositeScore = ComputeV3Composite(&outcome)
+	}
+	outcome.LastComputed = now
+	doc.V3 = &outcome
+
+	listed := false
+	for i := range doc.MRH.Witnessing {
+		if doc.MRH.Witnessing[i].LCTID == witness {
+			doc.MRH.Witnessing[i].LastAttestation = now
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		doc.MRH.Witnessing = append(doc.MRH.Witnessing, MRHWitnessing{
+			LCTID:           witness,
+			Role:            WitnessAction,
+			LastAttestation: now,
+		})
+	}
+	doc.MRH.LastUpdated = now
+	doc.Attestations = append(doc.Attestations, att)
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Service Entities
+// ═══════════════════════════════════════════════════════════════
+
+// ConstraintSLA is the Policy.Constraints key holding a service's SLA:
+//
+//	"sla": {"uptime": 0.999, "latency_ms": 250}
+const ConstraintSLA = "sla"
+
+// ServiceSLA returns the service's declared uptime (0.0-1.0) and latency
+// bound in milliseconds (>= 0). ValidateDocument requires a valid SLA on
+// every service-type document.
+func (doc *Document) ServiceSLA() (uptime float64, latencyMs float64, err error) {
+	if err := requireEntityType(doc, EntityService); err != nil {
+		return 0, 0, err
+	}
+
+	var sla map[string]interface{}
+	switch m := doc.Policy.Constraints[ConstraintSLA].(type) {
+	case map[string]interface{}:
+		sla = m
+	case map[string]float64:
+		sla = make(map[string]interface{}, len(m))
+		for k, v := range m {
+			sla[k] = v
+		}
+	case nil:
+		return 0, 0, fmt.Errorf("service missing policy.constraints.sla")
+	default:
+		return 0, 0, fmt.Errorf("policy.constraints.sla is %T, expected a map", m)
+	}
+
+	uptime, ok := toFloat(sla["uptime"])
+	if !ok {
+		return 0, 0, fmt.Errorf("policy.constraints.sla.uptime missing or non-numeric")
+	}
+	if uptime < 0 || uptime > 1 {
+		return 0, 0, fmt.Errorf("policy.constraints.sla.uptime must be 0.0-1.0, got %g", uptime)
+	}
+	latencyMs, ok = toFloat(sla["latency_ms"])
+	if !ok {
+		return 0, 0, fmt.Errorf("policy.constraints.sla.latency_ms missing or non-numeric")
+	}
+	if latencyMs < 0 {
+		return 0, 0, fmt.Errorf("policy.constraints.sla.latency_ms must be >= 0, got %g", latencyMs)
+	}
+	return uptime, latencyMs, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Infrastructure Entities
+// ═══════════════════════════════════════════════════════════════
+
+// BuildDependencyGraph maps each infrastructure document's LCT ID to the
+// sorted LCT IDs of documents that declare it as a BoundParent. If the
+// graph contains a dependency cycle (infrastructure that transitively
+// depends on itself), the graph is returned with an error naming the cycle.
+func BuildDependencyGraph(docs []*Document) (map[string][]string, error) {
+	graph := map[string][]string{}
+	for _, doc := range docs {
+		if doc.Binding.EntityType == EntityInfrastructure {
+			graph[doc.LCTID] = []string{}
+		}
+	}
+	for _, doc := range docs {
+		for _, b := range doc.MRH.Bound {
+			if b.Type != BoundParent {
+				continue
+			}
+			if deps, ok := graph[b.LCTID]; ok && !containsString(deps, doc.LCTID) {
+				graph[b.LCTID] = append(deps, doc.LCTID)
+			}
+		}
+	}
+
+	roots := make([]string, 0, len(graph))
+	for id, deps := range graph {
+		sort.Strings(deps)
+		roots = append(roots, id)
+	}
+	sort.Strings(roots)
+
+	// Depth-first search for back edges
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = inProgress
+		path = append(path, id)
+		for _, dep := range graph[id] {
+			switch state[dep] {
+			case inProgress:
+				for i, p := range path {
+					if p == dep {
+						return append(append([]string(nil), path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+	for _, id := range roots {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return graph, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " → "))
+			}
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,491 @@
+package lct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// docOfType returns minimalValidDoc() rebound to entity type et.
+func docOfType(et EntityType, lctID string) *Document {
+	doc := minimalValidDoc()
+	doc.LCTID = lctID
+	doc.Binding.EntityType = et
+	return doc
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Accumulator Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestAccumulatorSumsContributions(t *testing.T) {
+	acc, err := NewAccumulator(docOfType(EntityAccumulator, "lct:web4:accumulator:pool"))
+	if err != nil {
+		t.Fatalf("NewAccumulator failed: %v", err)
+	}
+
+	contributions := []V3Tensor{
+		{Valuation: 1.5, Veracity: 0.9, Validity: 0.6},
+		{Valuation: 2.0, Veracity: 0.7, Validity: 0.8},
+		{Valuation: 0.5, Veracity: 0.8, Validity: 0.7, ComputationWitnesses: []string{"lct:web4:oracle:meter"}},
+	}
+	for _, c := range contributions {
+		if err := acc.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	if math.Abs(acc.Total-4.0) > 0.001 {
+		t.Errorf("Expected total 4.0, got %f", acc.Total)
+	}
+	if len(acc.Doc.Attestations) != 3 {
+		t.Errorf("Expected 3 attestations, got %d", len(acc.Doc.Attestations))
+	}
+	if acc.Doc.Attestations[2].Witness != "lct:web4:oracle:meter" {
+		t.Errorf("Expected contribution attributed to its witness, got %q", acc.Doc.Attestations[2].Witness)
+	}
+
+	snap := acc.Snapshot()
+	if snap.Valuation != acc.Total {
+		t.Errorf("Snapshot valuation should equal total, got %f", snap.Valuation)
+	}
+	if math.Abs(snap.Veracity-0.8) > 0.001 {
+		t.Errorf("Expected averaged veracity 0.8, got %f", snap.Veracity)
+	}
+}
+
+func TestAccumulatorRejectsWrongEntityType(t *testing.T) {
+	if _, err := NewAccumulator(minimalValidDoc()); err == nil {
+		t.Error("Expected error wrapping a non-accumulator document")
+	}
+
+	acc := &Accumulator{Doc: minimalValidDoc()}
+	if err := acc.Add(V3Tensor{Valuation: 1}); err == nil {
+		t.Error("Expected Add to reject a non-accumulator document")
+	}
+}
+
+func TestAccumulatorAddSigned(t *testing.T) {
+	acc, err := NewAccumulator(docOfType(EntityAccumulator, "lct:web4:accumulator:pool"))
+	if err != nil {
+		t.Fatalf("NewAccumulator failed: %v", err)
+	}
+	if err := acc.AddSigned(V3Tensor{Valuation: 1}, ""); err == nil {
+		t.Fatal("Expected AddSigned to reject an empty signature")
+	}
+	if acc.Total != 0 || len(acc.Doc.Attestations) != 0 {
+		t.Errorf("Rejected contribution should not be recorded, got total %g and %d attestations", acc.Total, len(acc.Doc.Attestations))
+	}
+
+	if err := acc.AddSigned(V3Tensor{Valuation: 2}, "cose:contribution"); err != nil {
+		t.Fatalf("AddSigned failed: %v", err)
+	}
+	att := acc.Doc.Attestations[0]
+	if att.Sig != "cose:contribution" || att.TS == "" || acc.Total != 2 {
+		t.Errorf("Expected signed, timestamped contribution, got %+v (total %g)", att, acc.Total)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Dictionary Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestDictionaryAddAndLookupTerm(t *testing.T) {
+	doc := docOfType(EntityDictionary, "lct:web4:dictionary:medical")
+	if err := doc.AddTerm("physician", "lct:web4:role:physician"); err != nil {
+		t.Fatalf("AddTerm failed: %v", err)
+	}
+	if err := doc.AddTerm("nurse", "lct:web4:role:nurse"); err != nil {
+		t.Fatalf("AddTerm failed: %v", err)
+	}
+
+	id, ok := doc.LookupTerm("physician")
+	if !ok || id != "lct:web4:role:physician" {
+		t.Errorf("Expected physician → lct:web4:role:physician, got %q (%v)", id, ok)
+	}
+	if _, ok := doc.LookupTerm("surgeon"); ok {
+		t.Error("Expected unknown term lookup to fail")
+	}
+
+	// Terms survive a JSON round-trip
+	data, _ := json.Marshal(doc)
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if id, ok := restored.LookupTerm("nurse"); !ok || id != "lct:web4:role:nurse" {
+		t.Errorf("Expected nurse term after round-trip, got %q (%v)", id, ok)
+	}
+	if err := restored.AddTerm("orderly", "lct:web4:role:orderly"); err != nil {
+		t.Errorf("AddTerm on decoded terms map failed: %v", err)
+	}
+}
+
+func TestDictionaryTermGuard(t *testing.T) {
+	doc := minimalValidDoc()
+	if err := doc.AddTerm("physician", "lct:web4:role:physician"); err == nil {
+		t.Error("Expected AddTerm to reject a non-dictionary document")
+	}
+
+	doc.Policy.Constraints = map[string]interface{}{"terms": map[string]interface{}{"x": "lct:web4:role:x"}}
+	if _, ok := doc.LookupTerm("x"); ok {
+		t.Error("Expected LookupTerm to ignore terms on a non-dictionary document")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Oracle Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestOracleClaimRoundTrip(t *testing.T) {
+	doc := docOfType(EntityOracle, "lct:web4:oracle:weather")
+	claim := OracleClaim{
+		Subject:    "station:sfo",
+		Predicate:  "temperature_c",
+		Object:     "18.5",
+		Confidence: 0.95,
+		Source:     "https://weather.example/sfo",
+	}
+	if err := doc.AttestOracleClaim(claim, "cose:oracle_sig"); err != nil {
+		t.Fatalf("AttestOracleClaim failed: %v", err)
+	}
+	if doc.Attestations[0].Type != AttestationOracleClaim || doc.Attestations[0].Witness != doc.LCTID {
+		t.Errorf("Unexpected attestation: %+v", doc.Attestations[0])
+	}
+
+	// Round-trip through JSON so claims decode from generic values
+	data, _ := json.Marshal(doc)
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	claims, err := ParseOracleClaims(&restored)
+	if err != nil {
+		t.Fatalf("ParseOracleClaims failed: %v", err)
+	}
+	if len(claims) != 1 || claims[0] != claim {
+		t.Errorf("Expected %+v, got %+v", claim, claims)
+	}
+}
+
+func TestOracleClaimConfidenceOutOfRange(t *testing.T) {
+	doc := docOfType(EntityOracle, "lct:web4:oracle:weather")
+	err := doc.AttestOracleClaim(OracleClaim{Subject: "s", Predicate: "p", Confidence: 1.2}, "cose:sig")
+	if err == nil {
+		t.Fatal("Expected confidence 1.2 to be rejected")
+	}
+	if len(doc.Attestations) != 0 {
+		t.Error("Rejected claim should not be attested")
+	}
+}
+
+func TestOracleClaimGuard(t *testing.T) {
+	err := minimalValidDoc().AttestOracleClaim(OracleClaim{Subject: "s", Predicate: "p", Confidence: 0.5}, "cose:sig")
+	if err == nil {
+		t.Error("Expected AttestOracleClaim to reject a non-oracle document")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Role Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestEffectiveCapabilitiesUnionsRoles(t *testing.T) {
+	reviewer := docOfType(EntityRole, "lct:web4:role:reviewer")
+	reviewer.Policy.Capabilities = []string{"read:lct", "witness:attest"}
+	publisher := docOfType(EntityRole, "lct:web4:role:publisher")
+	publisher.Policy.Capabilities = []string{"write:lct", "read:lct"}
+
+	citizen := minimalValidDoc() // own capability: witness:attest
+	citizen.MRH.Paired = append(citizen.MRH.Paired,
+		MRHPaired{LCTID: reviewer.LCTID, PairingType: PairingRole},
+		MRHPaired{LCTID: publisher.LCTID, PairingType: PairingRole},
+		MRHPaired{LCTID: "lct:web4:service:unrelated", PairingType: PairingOperational},
+	)
+
+	caps, err := EffectiveCapabilities(citizen, NewMapResolver(reviewer, publisher))
+	if err != nil {
+		t.Fatalf("EffectiveCapabilities failed: %v", err)
+	}
+	assertEqual(t, "capabilities", "read:lct,witness:attest,write:lct", strings.Join(caps, ","))
+}
+
+func TestEffectiveCapabilitiesUnresolvedRole(t *testing.T) {
+	citizen := minimalValidDoc()
+	citizen.MRH.Paired = append(citizen.MRH.Paired, MRHPaired{LCTID: "lct:web4:role:ghost", PairingType: PairingRole})
+
+	_, err := EffectiveCapabilities(citizen, MapResolver{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unresolvable role, got %v", err)
+	}
+}
+
+func TestEffectiveCapabilitiesContextCanceled(t *testing.T) {
+	role := docOfType(EntityRole, "lct:web4:role:reviewer")
+	citizen := minimalValidDoc()
+	citizen.MRH.Paired = append(citizen.MRH.Paired, MRHPaired{LCTID: role.LCTID, PairingType: PairingRole})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := EffectiveCapabilitiesContext(ctx, citizen, NewMapResolver(role)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Society Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestVerifyMembership(t *testing.T) {
+	citizen := minimalValidDoc()
+	society := docOfType(EntitySociety, "lct:web4:society:genesis")
+	if err := VerifyMembership(citizen, society); err != nil {
+		t.Errorf("Expected valid membership, got %v", err)
+	}
+}
+
+func TestVerifyMembershipMismatchedSociety(t *testing.T) {
+	citizen := minimalValidDoc()
+	if err := VerifyMembership(citizen, docOfType(EntitySociety, "lct:web4:society:other")); err == nil {
+		t.Error("Expected error for a different society")
+	}
+	if err := VerifyMembership(citizen, docOfType(EntityOrganization, "lct:web4:society:genesis")); err == nil {
+		t.Error("Expected error for a non-society issuer")
+	}
+}
+
+func TestVerifyMembershipRevokedSociety(t *testing.T) {
+	society := docOfType(EntitySociety, "lct:web4:society:genesis")
+	society.Revocation = &Revocation{Status: RevocationRevoked, Reason: RevocationCompromise}
+	err := VerifyMembership(minimalValidDoc(), society)
+	if err == nil || !contains(err.Error(), "revoked") {
+		t.Errorf("Expected revoked society error, got %v", err)
+	}
+}
+
+func TestValidateSociety(t *testing.T) {
+	society := docOfType(EntitySociety, "lct:web4:society:genesis")
+
+	citizen := func(id, subject, issuer string, witnesses ...string) *Document {
+		doc := docOfType(EntityAI, id)
+		doc.Subject = subject
+		doc.BirthCert.IssuingSociety = issuer
+		if len(witnesses) > 0 {
+			doc.BirthCert.BirthWitnesses = witnesses
+		}
+		return doc
+	}
+	alice := citizen("lct:web4:ai:alice", "did:web4:key:alice", "lct:web4:society:genesis")
+	foreign := citizen("lct:web4:ai:mallory", "did:web4:key:mallory", "lct:web4:society:elsewhere")
+	bob := citizen("lct:web4:ai:bob", "did:web4:key:bob", "lct:web4:society:genesis",
+		"lct:web4:ai:alice", "lct:web4:ai:mallory", "lct:web4:witness:external")
+	twin := citizen("lct:web4:ai:twin", "did:web4:key:alice", "lct:web4:society:genesis")
+
+	results := ValidateSociety(society, []*Document{alice, foreign, bob, twin})
+	if len(results) != 4 {
+		t.Fatalf("Expected one result per citizen, got %d", len(results))
+	}
+	if !results[0].Valid {
+		t.Errorf("Expected alice to be valid, got %v", results[0].Errors)
+	}
+	if results[1].Valid || !contains(strings.Join(results[1].Errors, "\n"), "Society membership") {
+		t.Errorf("Expected foreign issuing society error, got %v", results[1].Errors)
+	}
+	if results[2].Valid || len(results[2].Errors) != 1 || !contains(results[2].Errors[0], `"lct:web4:ai:mallory" is not a member`) {
+		t.Errorf("Expected only the foreign witness to be reported, got %v", results[2].Errors)
+	}
+	if results[3].Valid || !contains(strings.Join(results[3].Errors, "\n"), "shared with citizen lct:web4:ai:alice") {
+		t.Errorf("Expected shared subject error, got %v", results[3].Errors)
+	}
+}
+
+func TestSocietyShortRefs(t *testing.T) {
+	society := docOfType(EntitySociety, "lct:web4:society:genesis")
+	if err := society.RegisterShortRef("alice", "lct:web4:ai:alice"); err != nil {
+		t.Fatalf("RegisterShortRef failed: %v", err)
+	}
+	id, err := ResolveShortRef(society, "alice")
+	if err != nil {
+		t.Fatalf("ResolveShortRef failed: %v", err)
+	}
+	assertEqual(t, "alice", "lct:web4:ai:alice", id)
+
+	// Namespace survives a JSON round-trip
+	data, _ := json.Marshal(society)
+	var restored Document
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if id, err := ResolveShortRef(&restored, "alice"); err != nil || id != "lct:web4:ai:alice" {
+		t.Errorf("Expected alice after round-trip, got %q (%v)", id, err)
+	}
+
+	if _, err := ResolveShortRef(society, "bob"); err == nil || !contains(err.Error(), "unknown short reference") {
+		t.Errorf("Expected unknown name error, got %v", err)
+	}
+	if err := minimalValidDoc().RegisterShortRef("alice", "lct:web4:ai:alice"); err == nil {
+		t.Error("Expected RegisterShortRef to reject a non-society document")
+	}
+	if err := society.RegisterShortRef("bob", "not-an-lct"); err == nil {
+		t.Error("Expected RegisterShortRef to reject an invalid LCT ID")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Task Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestCompleteTask(t *testing.T) {
+	doc := docOfType(EntityTask, "lct:web4:task:audit-q1")
+	doc.Policy.Constraints = map[string]interface{}{"status": TaskActive}
+	if status, ok := doc.TaskStatus(); !ok || status != TaskActive {
+		t.Fatalf("Expected active status, got %q (%v)", status, ok)
+	}
+
+	outcome := V3Tensor{Valuation: 0.8, Veracity: 0.9, Validity: 0.85}
+	if err := doc.CompleteTask(outcome, "lct:web4:witness:auditor", "cose:done"); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	if status, _ := doc.TaskStatus(); status != TaskCompleted {
+		t.Errorf("Expected completed status, got %q", status)
+	}
+	if doc.V3 == nil || doc.V3.Veracity != 0.9 || doc.V3.CompositeScore == 0 {
+		t.Errorf("Expected outcome V3 with computed composite, got %+v", doc.V3)
+	}
+	w := doc.MRH.Witnessing[len(doc.MRH.Witnessing)-1]
+	if w.LCTID != "lct:web4:witness:auditor" || w.Role != WitnessAction {
+		t.Errorf("Expected action witness, got %+v", w)
+	}
+	if len(doc.Attestations) != 1 || doc.Attestations[0].Type != AttestationTaskCompleted {
+		t.Errorf("Expected task:completed attestation, got %+v", doc.Attestations)
+	}
+}
+
+func TestCompleteTaskExistingWitness(t *testing.T) {
+	doc := docOfType(EntityTask, "lct:web4:task:audit-q1")
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:witness:auditor", Role: WitnessExistence, LastAttestation: "2026-02-19T00:00:00Z"}}
+
+	if err := doc.CompleteTask(V3Tensor{Valuation: 0.8}, "lct:web4:witness:auditor", "cose:done"); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	if len(doc.MRH.Witnessing) != 1 {
+		t.Fatalf("Expected witness to be refreshed, not duplicated, got %+v", doc.MRH.Witnessing)
+	}
+	w := doc.MRH.Witnessing[0]
+	if w.Role != WitnessExistence || w.LastAttestation != doc.Attestations[0].TS {
+		t.Errorf("Expected existing entry with refreshed timestamp, got %+v", w)
+	}
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected completed task to validate, got: %v", result.Errors)
+	}
+}
+
+func TestCompleteTaskAlreadyCompleted(t *testing.T) {
+	doc := docOfType(EntityTask, "lct:web4:task:audit-q1")
+	if err := doc.CompleteTask(V3Tensor{}, "lct:web4:witness:a", "cose:1"); err != nil {
+		t.Fatalf("First completion failed: %v", err)
+	}
+	if err := doc.CompleteTask(V3Tensor{}, "lct:web4:witness:b", "cose:2"); err == nil {
+		t.Error("Expected second completion to be rejected")
+	}
+	if len(doc.Attestations) != 1 {
+		t.Errorf("Rejected completion should not attest, got %d attestations", len(doc.Attestations))
+	}
+}
+
+func TestCompleteTaskGuard(t *testing.T) {
+	if err := minimalValidDoc().CompleteTask(V3Tensor{}, "lct:web4:witness:a", "cose:1"); err == nil {
+		t.Error("Expected CompleteTask to reject a non-task document")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Service Tests
+// ═══════════════════════════════════════════════════════════════
+
+func serviceDoc(sla interface{}) *Document {
+	doc := docOfType(EntityService, "lct:web4:service:telemetry")
+	if sla != nil {
+		doc.Policy.Constraints = map[string]interface{}{"sla": sla}
+	}
+	return doc
+}
+
+func TestServiceSLAValid(t *testing.T) {
+	doc := serviceDoc(map[string]interface{}{"uptime": 0.999, "latency_ms": 250.0})
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Expected valid service, got: %v", result.Errors)
+	}
+	uptime, latency, err := doc.ServiceSLA()
+	if err != nil || uptime != 0.999 || latency != 250 {
+		t.Errorf("Expected (0.999, 250), got (%g, %g, %v)", uptime, latency, err)
+	}
+}
+
+func TestServiceSLAMissing(t *testing.T) {
+	result := ValidateDocument(serviceDoc(nil))
+	if result.Valid {
+		t.Fatal("Expected service without SLA to be invalid")
+	}
+	if !contains(result.Errors[0], "sla") {
+		t.Errorf("Expected SLA error, got: %v", result.Errors)
+	}
+}
+
+func TestServiceSLAUptimeOutOfRange(t *testing.T) {
+	result := ValidateDocument(serviceDoc(map[string]float64{"uptime": 1.5, "latency_ms": 100}))
+	if result.Valid {
+		t.Fatal("Expected uptime 1.5 to be invalid")
+	}
+	if !contains(result.Errors[0], "uptime") {
+		t.Errorf("Expected uptime error, got: %v", result.Errors)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Infrastructure Tests
+// ═══════════════════════════════════════════════════════════════
+
+func boundTo(doc *Document, parents ...string) *Document {
+	for _, p := range parents {
+		doc.MRH.Bound = append(doc.MRH.Bound, MRHBound{LCTID: p, Type: BoundParent})
+	}
+	return doc
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	infra := docOfType(EntityInfrastructure, "lct:web4:infrastructure:cluster")
+	api := boundTo(docOfType(EntityService, "lct:web4:service:api"), infra.LCTID)
+	db := boundTo(docOfType(EntityService, "lct:web4:service:db"), infra.LCTID)
+	unrelated := docOfType(EntityService, "lct:web4:service:standalone")
+
+	graph, err := BuildDependencyGraph([]*Document{infra, db, api, unrelated})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(graph) != 1 {
+		t.Fatalf("Expected graph keyed by 1 infrastructure node, got %v", graph)
+	}
+	assertEqual(t, "dependents", "lct:web4:service:api,lct:web4:service:db", strings.Join(graph[infra.LCTID], ","))
+}
+
+func TestBuildDependencyGraphCycle(t *testing.T) {
+	a := boundTo(docOfType(EntityInfrastructure, "lct:web4:infrastructure:a"), "lct:web4:infrastructure:b")
+	b := boundTo(docOfType(EntityInfrastructure, "lct:web4:infrastructure:b"), "lct:web4:infrastructure:a")
+
+	graph, err := BuildDependencyGraph([]*Document{a, b})
+	if err == nil {
+		t.Fatal("Expected dependency cycle error")
+	}
+	if !strings.Contains(err.Error(), "cycle") || !strings.Contains(err.Error(), a.LCTID) {
+		t.Errorf("Expected cycle error naming %s, got %v", a.LCTID, err)
+	}
+	if len(graph) != 2 {
+		t.Errorf("Graph should still be returned with the error, got %v", graph)
+	}
+}
@@ -0,0 +1,192 @@
+package lct
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Multi-Network Identity
+// ═══════════════════════════════════════════════════════════════
+
+// IdentitySet groups the addresses of a single entity across networks.
+// Every member shares the same EntityID().
+type IdentitySet struct {
+	Identities []*Identity
+}
+
+// Add appends id to the set, rejecting identities that refer to a
+// different entity than the existing members.
+func (s *IdentitySet) Add(id *Identity) error {
+	if id == nil {
+		return fmt.Errorf("nil identity")
+	}
+	if len(s.Identities) > 0 {
+		if want := s.Identities[0].EntityID(); id.EntityID() != want {
+			return fmt.Errorf("identity %s refers to entity %q, set holds %q", id.Canonical(), id.EntityID(), want)
+		}
+	}
+	s.Identities = append(s.Identities, id)
+	return nil
+}
+
+// OnNetwork returns the first identity addressed on network.
+func (s *IdentitySet) OnNetwork(network string) (*Identity, bool) {
+	for _, id := range s.Identities {
+		if id.Network == network {
+			return id, true
+		}
+	}
+	return nil, false
+}
+
+// ParseURISet parses uris into an IdentitySet. URIs that fail to parse or
+// refer to a different entity than the first accepted URI are skipped and
+// reported in the returned errors.
+func ParseURISet(uris []string) (*IdentitySet, []error) {
+	set := &IdentitySet{}
+	var errs []error
+	for _, uri := range uris {
+		result := ParseURI(uri)
+		if !result.Success {
+			errs = append(errs, fmt.Errorf("%s: %s", uri, strings.Join(result.Errors, "; ")))
+			continue
+		}
+		if err := set.Add(result.Identity); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return set, errs
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Filtering
+// ═══════════════════════════════════════════════════════════════
+
+// TrustFilterOptions adjusts FilterURIsByTrustWithOptions.
+type TrustFilterOptions struct {
+	// DropUnset drops URIs that declare no trust_threshold.
+	DropUnset bool
+}
+
+// FilterURIsByTrust parses uris and keeps identities whose declared
+// trust_threshold is at least min. URIs without a trust_threshold are kept.
+// Parse failures are collected separately and do not stop filtering.
+func FilterURIsByTrust(uris []string, min float64) ([]*Identity, []error) {
+	return FilterURIsByTrustWithOptions(uris, min, TrustFilterOptions{})
+}
+
+// FilterURIsByTrustWithOptions is FilterURIsByTrust with options.
+func FilterURIsByTrustWithOptions(uris []string, min float64, opts TrustFilterOptions) ([]*Identity, []error) {
+	var kept []*Identity
+	var errs []error
+	for _, uri := range uris {
+		result := ParseURI(uri)
+		if !result.Success {
+			errs = append(errs, fmt.Errorf("%s: %s", uri, strings.Join(result.Errors, "; ")))
+			continue
+		}
+		id := result.Identity
+		if id.TrustThreshold < 0 {
+			if !opts.DropUnset {
+				kept = append(kept, id)
+			}
+			continue
+		}
+		if id.TrustThreshold >= min {
+			kept = append(kept, id)
+		}
+	}
+	return kept, errs
+}
+
+// ═══════════════════════════════════════════════════════════════
+// URI Matching
+// ═══════════════════════════════════════════════════════════════
+
+// URIMatcher accepts or rejects identities by pattern. Patterns have the
+// form "component:instance:role@network"; each segment is a glob ("*",
+// "expert_*", ...) and an omitted "@network" matches any network.
+// Deny patterns take precedence over allow patterns, and an empty allow
+// list admits every identity that is not denied. An identity whose role
+// is the AnyRole wildcard matches any role pattern.
+type URIMatcher struct {
+	allow []uriPattern
+	deny  []uriPattern
+}
+
+type uriPattern struct {
+	component, instance, role, network string
+}
+
+// NewURIMatcher compiles allow and deny pattern lists into a URIMatcher.
+func NewURIMatcher(allow, deny []string) (*URIMatcher, error) {
+	m := &URIMatcher{}
+	var err error
+	if m.allow, err = parseURIPatterns(allow); err != nil {
+		return nil, err
+	}
+	if m.deny, err = parseURIPatterns(deny); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func parseURIPatterns(patterns []string) ([]uriPattern, error) {
+	out := make([]uriPattern, 0, len(patterns))
+	for _, raw := range patterns {
+		body, network := raw, "*"
+		if at := strings.LastIndex(raw, "@"); at >= 0 {
+			body, network = raw[:at], raw[at+1:]
+		}
+		parts := strings.Split(body, ":")
+		if len(parts) != 3 || network == "" {
+			return nil, fmt.Errorf("invalid URI pattern %q: expected component:instance:role[@network]", raw)
+		}
+		p := uriPattern{component: parts[0], instance: parts[1], role: parts[2], network: network}
+		for _, seg := range []string{p.component, p.instance, p.role, p.network} {
+			if _, err := path.Match(seg, ""); seg == "" || err != nil {
+				return nil, fmt.Errorf("invalid URI pattern %q: bad segment %q", raw, seg)
+			}
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Matches reports whether id is admitted: it matches no deny pattern and,
+// if any allow patterns are set, at least one of them.
+func (m *URIMatcher) Matches(id *Identity) bool {
+	if id == nil {
+		return false
+	}
+	for _, p := range m.deny {
+		if p.matches(id) {
+			return false
+		}
+	}
+	if len(m.allow) == 0 {
+		return true
+	}
+	for _, p := range m.allow {
+		if p.matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p uriPattern) matches(id *Identity) bool {
+	return globMatch(p.component, id.Component) &&
+		globMatch(p.instance, id.Instance) &&
+		(id.isRoleWildcard() || globMatch(p.role, id.Role)) &&
+		globMatch(p.network, id.Network)
+}
+
+// globMatch matches a single pattern segment. Segments never contain "/",
+// so path.Match semantics apply directly; patterns are validated up front.
+func globMatch(pattern, s string) bool {
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
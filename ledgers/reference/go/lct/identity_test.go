@@ -0,0 +1,159 @@
+package lct
+
+import (
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// IdentitySet Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestParseURISetAcrossNetworks(t *testing.T) {
+	set, errs := ParseURISet([]string{
+		"lct://sage:thinker:expert@testnet",
+		"lct://sage:thinker:expert@mainnet",
+	})
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if len(set.Identities) != 2 {
+		t.Fatalf("Expected 2 identities, got %d", len(set.Identities))
+	}
+
+	id, ok := set.OnNetwork("mainnet")
+	if !ok {
+		t.Fatal("Expected identity on mainnet")
+	}
+	assertEqual(t, "network", "mainnet", id.Network)
+
+	if _, ok := set.OnNetwork("local"); ok {
+		t.Error("Expected no identity on local")
+	}
+}
+
+func TestParseURISetRejectsMixedEntities(t *testing.T) {
+	set, errs := ParseURISet([]string{
+		"lct://sage:thinker:expert@testnet",
+		"lct://mcp:filesystem:reader@mainnet",
+		"not-a-uri",
+	})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors (foreign entity + parse failure), got %v", errs)
+	}
+	if len(set.Identities) != 1 {
+		t.Errorf("Expected only the first entity to be kept, got %d", len(set.Identities))
+	}
+	if _, ok := set.OnNetwork("mainnet"); ok {
+		t.Error("Foreign entity should not be reachable on mainnet")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Filtering Tests
+// ═══════════════════════════════════════════════════════════════
+
+var trustFilterURIs = []string{
+	"lct://sage:thinker:high@testnet?trust_threshold=0.9",
+	"lct://sage:thinker:low@testnet?trust_threshold=0.3",
+	"lct://sage:thinker:edge@testnet?trust_threshold=0.5",
+	"lct://sage:thinker:unset@testnet",
+	"lct://broken",
+}
+
+func TestFilterURIsByTrust(t *testing.T) {
+	kept, errs := FilterURIsByTrust(trustFilterURIs, 0.5)
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 parse error, got %v", errs)
+	}
+
+	var roles []string
+	for _, id := range kept {
+		roles = append(roles, id.Role)
+	}
+	if len(roles) != 3 || roles[0] != "high" || roles[1] != "edge" || roles[2] != "unset" {
+		t.Errorf("Expected [high edge unset], got %v", roles)
+	}
+}
+
+func TestFilterURIsByTrustDropUnset(t *testing.T) {
+	kept, _ := FilterURIsByTrustWithOptions(trustFilterURIs, 0.5, TrustFilterOptions{DropUnset: true})
+	if len(kept) != 2 {
+		t.Fatalf("Expected 2 identities with thresholds >= 0.5, got %d", len(kept))
+	}
+	for _, id := range kept {
+		if id.Role == "unset" {
+			t.Error("Unset threshold should be dropped with DropUnset")
+		}
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// URI Matching Tests
+// ═══════════════════════════════════════════════════════════════
+
+func mustIdentity(t *testing.T, uri string) *Identity {
+	t.Helper()
+	result := ParseURI(uri)
+	if !result.Success {
+		t.Fatalf("ParseURI(%q) failed: %v", uri, result.Errors)
+	}
+	return result.Identity
+}
+
+func TestURIMatcherComponentWildcard(t *testing.T) {
+	m, err := NewURIMatcher([]string{"sage:*:*@testnet"}, nil)
+	if err != nil {
+		t.Fatalf("NewURIMatcher failed: %v", err)
+	}
+	if !m.Matches(mustIdentity(t, "lct://sage:thinker:expert_42@testnet")) {
+		t.Error("Expected sage identity on testnet to match")
+	}
+	if m.Matches(mustIdentity(t, "lct://mcp:filesystem:reader@testnet")) {
+		t.Error("Expected other component to be rejected")
+	}
+	if m.Matches(nil) {
+		t.Error("Expected nil identity to be rejected")
+	}
+}
+
+func TestURIMatcherNetwork(t *testing.T) {
+	m, err := NewURIMatcher([]string{"*:*:*@mainnet", "mcp:*:reader"}, nil)
+	if err != nil {
+		t.Fatalf("NewURIMatcher failed: %v", err)
+	}
+	if !m.Matches(mustIdentity(t, "lct://sage:thinker:expert@mainnet")) {
+		t.Error("Expected mainnet identity to match")
+	}
+	if m.Matches(mustIdentity(t, "lct://sage:thinker:expert@testnet")) {
+		t.Error("Expected testnet identity to be rejected")
+	}
+	if !m.Matches(mustIdentity(t, "lct://mcp:filesystem:reader@local")) {
+		t.Error("Expected pattern without network to match any network")
+	}
+}
+
+func TestURIMatcherDenyOverridesAllow(t *testing.T) {
+	m, err := NewURIMatcher([]string{"sage:*:*"}, []string{"sage:*:expert_*@testnet"})
+	if err != nil {
+		t.Fatalf("NewURIMatcher failed: %v", err)
+	}
+	if m.Matches(mustIdentity(t, "lct://sage:thinker:expert_42@testnet")) {
+		t.Error("Expected deny pattern to override allow")
+	}
+	if !m.Matches(mustIdentity(t, "lct://sage:thinker:expert_42@mainnet")) {
+		t.Error("Expected allow to apply outside the denied network")
+	}
+
+	denyOnly, _ := NewURIMatcher(nil, []string{"*:*:*@mainnet"})
+	if !denyOnly.Matches(mustIdentity(t, "lct://sage:thinker:expert@testnet")) {
+		t.Error("Expected empty allow list to admit undenied identities")
+	}
+}
+
+func TestURIMatcherInvalidPatterns(t *testing.T) {
+	for _, p := range []string{"sage:*", "sage:*:*@", "sage::*", "sage:[:*"} {
+		if _, err := NewURIMatcher([]string{p}, nil); err == nil {
+			t.Errorf("Expected error for pattern %q", p)
+		}
+	}
+}
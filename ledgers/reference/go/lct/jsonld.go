@@ -0,0 +1,125 @@
+package lct
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JSONLDVocab is the web4 vocabulary namespace IRI, matching
+// web4-standard/schemas/contexts/lct.jsonld.
+const JSONLDVocab = "https://web4.io/ns/"
+
+// jsonLDTerms lists every JSON key emitted by Document and its nested
+// types. Each is mapped to web4:{camelCase} in the emitted @context.
+var jsonLDTerms = []string{
+	"schema_version", "lct_id", "subject", "binding", "entity_type", "public_key", "hardware_anchor",
+	"created_at", "binding_proof", "additional_proofs", "birth_certificate", "issuing_society",
+	"citizen_role", "context", "birth_timestamp", "parent_entity", "birth_witnesses",
+	"mrh", "bound", "paired", "witnessing", "horizon_depth", "last_updated",
+	"type", "ts", "pairing_type", "permanent", "session_id", "role",
+	"last_attestation", "policy", "capabilities", "constraints",
+	"t3_tensor", "talent", "training", "temperament",
+	"v3_tensor", "valuation", "veracity", "validity",
+	"sub_dimensions", "weights", "composite_score", "last_computed", "computation_witnesses",
+	"attestations", "witness", "sig", "claims", "co_signatures", "nonce", "lineage", "parent", "reason",
+	"revocation", "status", "trust_history",
+}
+
+// entityTypeClasses maps entity types to their web4 vocabulary class names.
+var entityTypeClasses = map[EntityType]string{
+	EntityHuman:          "Human",
+	EntityAI:             "AI",
+	EntitySociety:        "Society",
+	EntityOrganization:   "Organization",
+	EntityRole:           "Role",
+	EntityTask:           "Task",
+	EntityResource:       "Resource",
+	EntityDevice:         "Device",
+	EntityService:        "Service",
+	EntityOracle:         "Oracle",
+	EntityAccumulator:    "Accumulator",
+	EntityDictionary:     "Dictionary",
+	EntityHybrid:         "Hybrid",
+	EntityPolicy:         "Policy",
+	EntityInfrastructure: "Infrastructure",
+}
+
+// MarshalJSONLD serializes the document as JSON-LD: the plain JSON form
+// plus an inline @context mapping field names to web4 vocabulary IRIs and
+// an @type naming the LCT class and the entity class. Tensor sub-dimension
+// keys are expanded to web4: terms so they resolve against the
+// web4:subDimensionOf graph.
+func (doc *Document) MarshalJSONLD() ([]byte, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{"t3_tensor", "v3_tensor"} {
+		tensor, ok := obj[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if subs, ok := tensor["sub_dimensions"].(map[string]interface{}); ok {
+			tensor["sub_dimensions"] = expandSubDimensions(subs)
+		}
+	}
+
+	types := []string{"web4:LinkedContextToken"}
+	if class, ok := entityTypeClasses[doc.Binding.EntityType]; ok {
+		types = append(types, "web4:"+class)
+	}
+	obj["@context"] = jsonLDContext()
+	obj["@type"] = types
+
+	return json.Marshal(obj)
+}
+
+func jsonLDContext() map[string]interface{} {
+	ctx := map[string]interface{}{
+		"@version": 1.1,
+		"web4":     JSONLDVocab,
+	}
+	for _, term := range jsonLDTerms {
+		ctx[term] = "web4:" + snakeToCamel(term)
+	}
+	return ctx
+}
+
+// expandSubDimensions prefixes root and child sub-dimension keys with
+// "web4:" unless they are already compact IRIs.
+func expandSubDimensions(subs map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(subs))
+	for root, children := range subs {
+		if m, ok := children.(map[string]interface{}); ok {
+			expanded := make(map[string]interface{}, len(m))
+			for k, v := range m {
+				expanded[web4Term(k)] = v
+			}
+			children = expanded
+		}
+		out[web4Term(root)] = children
+	}
+	return out
+}
+
+func web4Term(key string) string {
+	if strings.Contains(key, ":") {
+		return key
+	}
+	return "web4:" + key
+}
+
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
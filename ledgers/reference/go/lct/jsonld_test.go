@@ -0,0 +1,102 @@
+package lct
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// JSON-LD Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestMarshalJSONLDContext(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3.SubDimensions = map[string]map[string]float64{
+		"talent": {"code_review": 0.8},
+	}
+
+	data, err := doc.MarshalJSONLD()
+	if err != nil {
+		t.Fatalf("MarshalJSONLD failed: %v", err)
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	ctx, ok := obj["@context"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected @context object")
+	}
+	if ctx["web4"] != JSONLDVocab {
+		t.Errorf("Expected web4 prefix %q, got %v", JSONLDVocab, ctx["web4"])
+	}
+	if ctx["lct_id"] != "web4:lctId" {
+		t.Errorf("Expected lct_id → web4:lctId, got %v", ctx["lct_id"])
+	}
+
+	types, ok := obj["@type"].([]interface{})
+	if !ok || len(types) != 2 || types[1] != "web4:AI" {
+		t.Errorf("Expected @type [web4:LinkedContextToken web4:AI], got %v", obj["@type"])
+	}
+
+	t3 := obj["t3_tensor"].(map[string]interface{})
+	subs := t3["sub_dimensions"].(map[string]interface{})
+	talent, ok := subs["web4:talent"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected sub-dimension root web4:talent, got %v", subs)
+	}
+	if talent["web4:code_review"] != 0.8 {
+		t.Errorf("Expected web4:code_review=0.8, got %v", talent)
+	}
+}
+
+func TestMarshalJSONLDLeavesPlainJSONUnchanged(t *testing.T) {
+	doc := minimalValidDoc()
+	if _, err := doc.MarshalJSONLD(); err != nil {
+		t.Fatalf("MarshalJSONLD failed: %v", err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var obj map[string]interface{}
+	json.Unmarshal(data, &obj)
+	if _, ok := obj["@context"]; ok {
+		t.Error("Plain JSON should not carry @context")
+	}
+}
+
+func TestJSONLDContextCoversDocumentFields(t *testing.T) {
+	ctx := jsonLDContext()
+	seen := map[reflect.Type]bool{}
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if _, ok := ctx[name]; !ok {
+				t.Errorf("JSON-LD context is missing term %q (%s.%s)", name, typ.Name(), field.Name)
+			}
+			walk(field.Type)
+		}
+	}
+	walk(reflect.TypeOf(Document{}))
+}
@@ -0,0 +1,139 @@
+package lct
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Multibase Keys
+// ═══════════════════════════════════════════════════════════════
+
+// base58Alphabet is the Bitcoin base58 alphabet used by multibase "z".
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// DecodePublicKey decodes a multibase-encoded public key. Supported
+// prefixes: "z" (base58btc), "m" (base64), "u" (base64url), and "f"
+// (base16).
+func DecodePublicKey(multibaseKey string) ([]byte, error) {
+	if len(multibaseKey) < 2 {
+		return nil, fmt.Errorf("invalid multibase key: %q is too short", multibaseKey)
+	}
+	prefix, body := multibaseKey[0], multibaseKey[1:]
+	var key []byte
+	var err error
+	switch prefix {
+	case 'z':
+		key, err = decodeBase58(body)
+	case 'm':
+		key, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(body, "="))
+	case 'u':
+		key, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(body, "="))
+	case 'f':
+		key, err = hex.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unsupported multibase prefix %q", prefix)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid multibase key: %w", err)
+	}
+	return key, nil
+}
+
+// SubjectFromPublicKey derives the canonical subject DID from a multibase
+// public key, "did:web4:key:z<base58btc key>", following did:key
+// conventions. The same key material always yields the same DID,
+// regardless of the multibase encoding it was supplied in.
+func SubjectFromPublicKey(multibaseKey string) (string, error) {
+	key, err := DecodePublicKey(multibaseKey)
+	if err != nil {
+		return "", err
+	}
+	return "did:web4:key:z" + encodeBase58(key), nil
+}
+
+func encodeBase58(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	// Repeated division of the big-endian number by 58
+	digits := make([]byte, 0, len(b)*138/100+1)
+	for _, c := range b[zeros:] {
+		carry := int(c)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = base58Alphabet[0]
+	}
+	for i, d := range digits {
+		out[len(out)-1-i] = base58Alphabet[d]
+	}
+	return string(out)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	bytes := make([]byte, 0, len(s))
+	for _, r := range s[zeros:] {
+		carry := strings.IndexRune(base58Alphabet, r)
+		if carry < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		for i := range bytes {
+			carry += int(bytes[i]) * 58
+			bytes[i] = byte(carry & 0xff)
+			carry >>= 8
+		}
+		for carry > 0 {
+			bytes = append(bytes, byte(carry&0xff))
+			carry >>= 8
+		}
+	}
+	out := make([]byte, zeros+len(bytes))
+	for i, b := range bytes {
+		out[len(out)-1-i] = b
+	}
+	return out, nil
+}
+
+// subjectKey returns the key material embedded in a did:web4:key subject.
+// It reports false for method DIDs and for subjects whose key is not a
+// decodable multibase value, so callers skip the comparison.
+func subjectKey(subject string) ([]byte, bool) {
+	const prefix = "did:web4:key:"
+	if !strings.HasPrefix(subject, prefix) {
+		return nil, false
+	}
+	key, err := DecodePublicKey(subject[len(prefix):])
+	if err != nil {
+		return nil, false
+	}
+	return key, true
+}
+
+// genesisPublicKey returns the public key the document was first bound to:
+// the parent of its earliest rotation lineage entry. It reports false when
+// the binding has never been rotated.
+func (doc *Document) genesisPublicKey() (string, bool) {
+	for _, l := range doc.Lineage {
+		if l.Reason == LineageRotation && l.Parent != "" {
+			return l.Parent, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package lct
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Multibase Key Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestBase58RoundTrip(t *testing.T) {
+	for _, in := range [][]byte{{}, {0}, {0, 0, 1}, []byte("hello world"), {0xed, 0x01, 0xff, 0x00}} {
+		out, err := decodeBase58(encodeBase58(in))
+		if err != nil || !bytes.Equal(in, out) {
+			t.Errorf("Round trip of %x gave %x (err=%v)", in, out, err)
+		}
+	}
+	assertEqual(t, "hello world", "StV1DL6CwTryKyV", encodeBase58([]byte("hello world")))
+}
+
+func TestSubjectFromPublicKeyDeterministic(t *testing.T) {
+	a, err := SubjectFromPublicKey("f68656c6c6f20776f726c64")
+	if err != nil {
+		t.Fatalf("SubjectFromPublicKey failed: %v", err)
+	}
+	b, _ := SubjectFromPublicKey("f68656c6c6f20776f726c64")
+	c, _ := SubjectFromPublicKey("maGVsbG8gd29ybGQ")
+	d, _ := SubjectFromPublicKey("zStV1DL6CwTryKyV")
+
+	assertEqual(t, "subject", "did:web4:key:zStV1DL6CwTryKyV", a)
+	assertEqual(t, "repeat", a, b)
+	assertEqual(t, "base64", a, c)
+	assertEqual(t, "base58", a, d)
+}
+
+func TestSubjectFromPublicKeyInvalid(t *testing.T) {
+	for _, key := range []string{"", "z", "z0OIl", "fzz", "xabc"} {
+		if _, err := SubjectFromPublicKey(key); err == nil {
+			t.Errorf("Expected error for key %q", key)
+		}
+	}
+}
+
+func TestBuilderWithSubjectFromKey(t *testing.T) {
+	doc := NewBuilder(EntityAI, "keyed").
+		WithBinding("zStV1DL6CwTryKyV", "cose:test_proof").
+		WithSubjectFromKey().
+		BuildUnsafe()
+	assertEqual(t, "subject", "did:web4:key:zStV1DL6CwTryKyV", doc.Subject)
+
+	_, err := NewBuilder(EntityAI, "keyed").
+		WithBinding("z0OIl", "cose:test_proof").
+		WithSubjectFromKey().
+		Build()
+	if err == nil {
+		t.Error("Expected build error for an invalid key")
+	}
+}
+
+func TestValidateSubjectMatchesBindingKey(t *testing.T) {
+	const mismatch = "binding.public_key does not match"
+
+	doc := minimalValidDoc()
+	doc.Subject = "did:web4:key:zStV1DL6CwTryKyV"
+	doc.Binding.PublicKey = "f68656c6c6f20776f726c64"
+	if result := ValidateDocument(doc); !result.Valid {
+		t.Errorf("Expected matching key pair to validate, got %v", result.Errors)
+	}
+
+	doc.Binding.PublicKey = "maGVsbG8gd29ybGQh"
+	result := ValidateDocument(doc)
+	if result.Valid || !contains(strings.Join(result.Errors, "\n"), mismatch) {
+		t.Errorf("Expected subject/key mismatch error, got %v", result.Errors)
+	}
+
+	doc.Subject = "did:web4:method:abc123"
+	for _, e := range ValidateDocument(doc).Errors {
+		if contains(e, mismatch) {
+			t.Errorf("Method DID subject should skip the key check, got %q", e)
+		}
+	}
+}
+
+func TestValidateSubjectKeyAfterRotation(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Subject = "did:web4:key:zStV1DL6CwTryKyV"
+	doc.Binding.PublicKey = "zStV1DL6CwTryKyV"
+
+	rotated, err := RotateBinding(doc, "maGVsbG8gd29ybGQh", "cose:new_proof", "cose:continuity")
+	if err != nil {
+		t.Fatalf("RotateBinding failed: %v", err)
+	}
+	if result := ValidateDocument(rotated); !result.Valid {
+		t.Fatalf("Expected rotated key-derived document to validate, got %v", result.Errors)
+	}
+
+	twice, err := RotateBinding(rotated, "f68656c6c6f", "cose:newer_proof", "cose:continuity2")
+	if err != nil {
+		t.Fatalf("Second RotateBinding failed: %v", err)
+	}
+	if result := ValidateDocument(twice); !result.Valid {
+		t.Errorf("Expected twice-rotated document to validate, got %v", result.Errors)
+	}
+
+	twice.Lineage[0].Parent = "maGVsbG8gd29ybGQh"
+	result := ValidateDocument(twice)
+	if result.Valid || !contains(strings.Join(result.Errors, "\n"), "Genesis key in lineage does not match") {
+		t.Errorf("Expected genesis key mismatch error, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,66 @@
+package lct
+
+import "fmt"
+
+// CurrentSchemaVersion is the document schema version produced by Builder
+// and targeted by UpgradeDocument. Documents without a schema_version
+// predate versioning and are treated as version 0.
+const CurrentSchemaVersion = 1
+
+// migrations[v] upgrades a document from schema version v to v+1 in place.
+var migrations = []func(doc *Document) error{
+	migrateV0ToV1,
+}
+
+// UpgradeDocument returns a copy of doc migrated to CurrentSchemaVersion by
+// applying each pending migration in order. Documents already at the
+// current version are returned unchanged; documents from a newer schema are
+// rejected.
+func UpgradeDocument(doc *Document) (*Document, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("nil document")
+	}
+	if doc.SchemaVersion < 0 || doc.SchemaVersion > CurrentSchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %d (current is %d)", doc.SchemaVersion, CurrentSchemaVersion)
+	}
+	upgraded := doc.Clone()
+	for v := upgraded.SchemaVersion; v < CurrentSchemaVersion; v++ {
+		if err := migrations[v](upgraded); err != nil {
+			return nil, fmt.Errorf("migrating schema version %d to %d: %w", v, v+1, err)
+		}
+		upgraded.SchemaVersion = v + 1
+	}
+	return upgraded, nil
+}
+
+// migrateV0ToV1 fills the defaults Builder has always produced but
+// pre-versioning documents may lack: non-nil capability and MRH lists, a
+// horizon depth, an explicit active revocation status, and tensor
+// composite scores.
+func migrateV0ToV1(doc *Document) error {
+	if doc.Policy.Capabilities == nil {
+		doc.Policy.Capabilities = []string{}
+	}
+	if doc.MRH.Bound == nil {
+		doc.MRH.Bound = []MRHBound{}
+	}
+	if doc.MRH.Paired == nil {
+		doc.MRH.Paired = []MRHPaired{}
+	}
+	if doc.MRH.Witnessing == nil {
+		doc.MRH.Witnessing = []MRHWitnessing{}
+	}
+	if doc.MRH.HorizonDepth == 0 {
+		doc.MRH.HorizonDepth = 3
+	}
+	if doc.Revocation == nil {
+		doc.Revocation = &Revocation{Status: RevocationActive}
+	}
+	if doc.T3 != nil && doc.T3.CompositeScore == 0 {
+		doc.T3.CompositeScore = ComputeT3Composite(doc.T3)
+	}
+	if doc.V3 != nil && doc.V3.CompositeScore == 0 {
+		doc.V3.CompositeScore = ComputeV3Composite(doc.V3)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package lct
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Schema Migration Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestUpgradeDocumentFromVersion0(t *testing.T) {
+	raw := `{
+		"lct_id": "lct:web4:ai:legacy",
+		"subject": "did:web4:key:z6Mklegacy",
+		"binding": {"entity_type": "ai", "public_key": "mb64testkey", "created_at": "2025-01-01T00:00:00Z"},
+		"birth_certificate": {"citizen_role": "lct:web4:role:citizen:ai"},
+		"mrh": {"paired": [{"lct_id": "lct:web4:role:citizen:ai", "pairing_type": "birth_certificate", "permanent": true}]},
+		"policy": {},
+		"t3_tensor": {"talent": 0.5, "training": 0.5, "temperament": 0.5}
+	}`
+	var doc Document
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if doc.SchemaVersion != 0 {
+		t.Fatalf("Expected absent schema_version to decode as 0, got %d", doc.SchemaVersion)
+	}
+
+	upgraded, err := UpgradeDocument(&doc)
+	if err != nil {
+		t.Fatalf("UpgradeDocument failed: %v", err)
+	}
+	if upgraded.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", CurrentSchemaVersion, upgraded.SchemaVersion)
+	}
+	if upgraded.Policy.Capabilities == nil || upgraded.MRH.Bound == nil || upgraded.MRH.Witnessing == nil {
+		t.Error("Expected nil lists to be filled")
+	}
+	if upgraded.MRH.HorizonDepth != 3 {
+		t.Errorf("Expected horizon depth 3, got %d", upgraded.MRH.HorizonDepth)
+	}
+	if upgraded.Revocation == nil || upgraded.Revocation.Status != RevocationActive {
+		t.Errorf("Expected active revocation status, got %+v", upgraded.Revocation)
+	}
+	if math.Abs(upgraded.T3.CompositeScore-0.5) > 0.001 {
+		t.Errorf("Expected computed T3 composite 0.5, got %f", upgraded.T3.CompositeScore)
+	}
+	if doc.SchemaVersion != 0 || doc.Revocation != nil {
+		t.Error("Original document should be unchanged")
+	}
+}
+
+func TestUpgradeDocumentIdempotent(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.SchemaVersion = CurrentSchemaVersion
+
+	upgraded, err := UpgradeDocument(doc)
+	if err != nil {
+		t.Fatalf("UpgradeDocument failed: %v", err)
+	}
+	if !reflect.DeepEqual(doc, upgraded) {
+		t.Error("Upgrading a current document should not change it")
+	}
+	again, err := UpgradeDocument(upgraded)
+	if err != nil || !reflect.DeepEqual(upgraded, again) {
+		t.Errorf("Second upgrade changed the document (err=%v)", err)
+	}
+}
+
+func TestUpgradeDocumentFutureVersion(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.SchemaVersion = CurrentSchemaVersion + 1
+	if _, err := UpgradeDocument(doc); err == nil {
+		t.Error("Expected error for a newer schema version")
+	}
+}
@@ -0,0 +1,421 @@
+package lct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// MRH Mutation
+// ═══════════════════════════════════════════════════════════════
+
+// UpsertPaired replaces the paired entry with the same LCTID or appends p
+// if none exists, then refreshes LastUpdated. A permanent birth_certificate
+// pairing cannot be overridden by a non-permanent or differently-typed entry.
+func (m *MRH) UpsertPaired(p MRHPaired) error {
+	if p.LCTID == "" {
+		return fmt.Errorf("paired entry missing lct_id")
+	}
+	now := Now().UTC().Format(time.RFC3339)
+	if p.TS == "" {
+		p.TS = now
+	}
+
+	for i, existing := range m.Paired {
+		if existing.LCTID != p.LCTID {
+			continue
+		}
+		if existing.PairingType == PairingBirthCertificate && existing.Permanent &&
+			(p.PairingType != PairingBirthCertificate || !p.Permanent) {
+			return fmt.Errorf("cannot override permanent birth_certificate pairing for %s", p.LCTID)
+		}
+		m.Paired[i] = p
+		m.LastUpdated = now
+		return nil
+	}
+
+	m.Paired = append(m.Paired, p)
+	m.LastUpdated = now
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Canonical Ordering
+// ═══════════════════════════════════════════════════════════════
+
+// Canonicalize sorts the Bound, Paired, and Witnessing slices by LCTID,
+// then timestamp, so the same relationships always serialize identically.
+func (m *MRH) Canonicalize() {
+	sort.SliceStable(m.Bound, func(i, j int) bool {
+		a, b := m.Bound[i], m.Bound[j]
+		if a.LCTID != b.LCTID {
+			return a.LCTID < b.LCTID
+		}
+		return a.TS < b.TS
+	})
+	sort.SliceStable(m.Paired, func(i, j int) bool {
+		a, b := m.Paired[i], m.Paired[j]
+		if a.LCTID != b.LCTID {
+			return a.LCTID < b.LCTID
+		}
+		return a.TS < b.TS
+	})
+	sort.SliceStable(m.Witnessing, func(i, j int) bool {
+		a, b := m.Witnessing[i], m.Witnessing[j]
+		if a.LCTID != b.LCTID {
+			return a.LCTID < b.LCTID
+		}
+		return a.LastAttestation < b.LastAttestation
+	})
+}
+
+// Canonicalize puts the document into canonical order in place.
+// Hash applies it to a copy, so callers need not canonicalize first.
+func (doc *Document) Canonicalize() {
+	doc.MRH.Canonicalize()
+}
+
+// copyMRHSlices returns m with its slices copied, preserving nil slices so
+// the JSON form (null vs []) is unchanged.
+func copyMRHSlices(m MRH) MRH {
+	if m.Bound != nil {
+		m.Bound = append(make([]MRHBound, 0, len(m.Bound)), m.Bound...)
+	}
+	if m.Paired != nil {
+		m.Paired = append(make([]MRHPaired, 0, len(m.Paired)), m.Paired...)
+	}
+	if m.Witnessing != nil {
+		m.Witnessing = append(make([]MRHWitnessing, 0, len(m.Witnessing)), m.Witnessing...)
+	}
+	return m
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Revocation Propagation
+// ═══════════════════════════════════════════════════════════════
+
+// PropagateRevocation notifies the entities paired with a revoked LCT.
+// Each partner named in revoked.MRH.Paired is fetched through resolver and,
+// if it lists the revoked LCT in its own paired set, apply is called to set
+// that pairing's status to PairingRevoked. Permanent birth_certificate
+// pairings cannot be unpaired and are skipped on either side.
+//
+// Failures for individual partners do not stop propagation; they are
+// returned joined.
+func PropagateRevocation(revoked *Document, resolver Resolver, apply func(doc *Document, newStatus PairingStatus) error) error {
+	return PropagateRevocationContext(context.Background(), revoked, resolver, apply)
+}
+
+// PropagateRevocationContext is PropagateRevocation with ctx passed to
+// every partner lookup. Cancelling ctx stops propagation before the next
+// partner.
+func PropagateRevocationContext(ctx context.Context, revoked *Document, resolver Resolver, apply func(doc *Document, newStatus PairingStatus) error) error {
+	if revoked.Revocation == nil || revoked.Revocation.Status != RevocationRevoked {
+		return fmt.Errorf("document %s is not revoked", revoked.LCTID)
+	}
+
+	var errs []error
+	for _, p := range revoked.MRH.Paired {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if p.PairingType == PairingBirthCertificate && p.Permanent {
+			continue
+		}
+		partner, err := resolver.Resolve(ctx, p.LCTID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resolving %s: %w", p.LCTID, err))
+			continue
+		}
+		for _, back := range partner.MRH.Paired {
+			if back.LCTID != revoked.LCTID {
+				continue
+			}
+			if back.PairingType == PairingBirthCertificate && back.Permanent {
+				break
+			}
+			if err := apply(partner, PairingRevoked); err != nil {
+				errs = append(errs, fmt.Errorf("revoking pairing on %s: %w", partner.LCTID, err))
+			}
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Horizon Traversal
+// ═══════════════════════════════════════════════════════════════
+
+// mrhNeighbors returns the LCT IDs directly related to doc through bound
+// and paired edges, plus witnessing edges if requested, in MRH order.
+func mrhNeighbors(doc *Document, witnessing bool) []string {
+	ids := make([]string, 0, len(doc.MRH.Bound)+len(doc.MRH.Paired)+len(doc.MRH.Witnessing))
+	for _, b := range doc.MRH.Bound {
+		ids = append(ids, b.LCTID)
+	}
+	for _, p := range doc.MRH.Paired {
+		ids = append(ids, p.LCTID)
+	}
+	if witnessing {
+		for _, w := range doc.MRH.Witnessing {
+			ids = append(ids, w.LCTID)
+		}
+	}
+	return ids
+}
+
+// HorizonEntities returns every LCT ID reachable from doc through bound and
+// paired edges within MRH.HorizonDepth hops, deduplicated and ordered
+// nearest first. The document itself is excluded. References the resolver
+// cannot find (ErrNotFound) are included but not expanded; any other
+// resolver error aborts the traversal.
+func (doc *Document) HorizonEntities(resolver Resolver) ([]string, error) {
+	return doc.HorizonEntitiesContext(context.Background(), resolver)
+}
+
+// HorizonEntitiesContext is HorizonEntities with ctx passed to every
+// lookup, so cancellation aborts the traversal.
+func (doc *Document) HorizonEntitiesContext(ctx context.Context, resolver Resolver) ([]string, error) {
+	visited := map[string]bool{doc.LCTID: true}
+	var reachable []string
+	frontier := []*Document{doc}
+
+	for depth := 1; depth <= doc.MRH.HorizonDepth && len(frontier) > 0; depth++ {
+		var next []*Document
+		for _, current := range frontier {
+			for _, id := range mrhNeighbors(current, false) {
+				if visited[id] {
+					continue
+				}
+				visited[id] = true
+				reachable = append(reachable, id)
+				if depth == doc.MRH.HorizonDepth {
+					continue
+				}
+				neighbor, err := resolver.Resolve(ctx, id)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					return reachable, fmt.Errorf("resolving %s: %w", id, err)
+				}
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+	}
+	return reachable, nil
+}
+
+// EffectiveHorizonDepth walks bound and paired edges from doc without a
+// depth limit and returns the largest hop count at which a new entity is
+// first reached, for comparison with the declared MRH.HorizonDepth. Each
+// entity is visited once, so cycles terminate. Unresolvable references
+// (ErrNotFound) count at their depth but are not expanded; any other
+// resolver error aborts the walk.
+func (doc *Document) EffectiveHorizonDepth(resolver Resolver) (int, error) {
+	return doc.EffectiveHorizonDepthContext(context.Background(), resolver)
+}
+
+// EffectiveHorizonDepthContext is EffectiveHorizonDepth with ctx passed to
+// every lookup, so cancellation aborts the walk.
+func (doc *Document) EffectiveHorizonDepthContext(ctx context.Context, resolver Resolver) (int, error) {
+	visited := map[string]bool{doc.LCTID: true}
+	frontier := []*Document{doc}
+	depth := 0
+
+	for len(frontier) > 0 {
+		var next []*Document
+		reached := false
+		for _, current := range frontier {
+			for _, id := range mrhNeighbors(current, false) {
+				if visited[id] {
+					continue
+				}
+				visited[id] = true
+				reached = true
+				neighbor, err := resolver.Resolve(ctx, id)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					return depth, fmt.Errorf("resolving %s: %w", id, err)
+				}
+				next = append(next, neighbor)
+			}
+		}
+		if reached {
+			depth++
+		}
+		frontier = next
+	}
+	return depth, nil
+}
+
+// FindTrustPath returns the shortest chain of LCT IDs linking from to to
+// through bound, paired, and witnessing edges, both endpoints included.
+// The search is breadth-first and stops after maxDepth hops; when no path
+// exists within it the error wraps ErrNoRelationship. Unresolvable
+// intermediate references (ErrNotFound) are not expanded; any other
+// resolver error aborts the search.
+func FindTrustPath(from, to string, resolver Resolver, maxDepth int) ([]string, error) {
+	return FindTrustPathContext(context.Background(), from, to, resolver, maxDepth)
+}
+
+// FindTrustPathContext is FindTrustPath with ctx passed to every lookup,
+// so cancellation aborts the search.
+func FindTrustPathContext(ctx context.Context, from, to string, resolver Resolver, maxDepth int) ([]string, error) {
+	if from == to {
+		return []string{from}, nil
+	}
+	start, err := resolver.Resolve(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", from, err)
+	}
+
+	prev := map[string]string{from: ""}
+	frontier := []*Document{start}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		var next []*Document
+		for _, current := range frontier {
+			for _, id := range mrhNeighbors(current, true) {
+				if _, seen := prev[id]; seen {
+					continue
+				}
+				prev[id] = current.LCTID
+				if id == to {
+					return tracePath(prev, to), nil
+				}
+				if depth == maxDepth {
+					continue
+				}
+				neighbor, err := resolver.Resolve(ctx, id)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("resolving %s: %w", id, err)
+				}
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+	}
+	return nil, fmt.Errorf("%w: no path from %s to %s within %d hops", ErrNoRelationship, from, to, maxDepth)
+}
+
+// tracePath follows prev links back from id and returns the path in
+// forward order.
+func tracePath(prev map[string]string, id string) []string {
+	var path []string
+	for ; id != ""; id = prev[id] {
+		path = append(path, id)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// ═══════════════════════════════════════════════════════════════
+// References
+// ═══════════════════════════════════════════════════════════════
+
+// ReferencedLCTIDs returns every LCT ID the document references in its
+// birth certificate (issuing society, citizen role, parent entity, birth
+// witnesses), MRH, and lineage parents, deduplicated and sorted. The
+// document's own LCT ID is excluded, as are rotation lineage parents,
+// which record a previous public key rather than an LCT.
+func (doc *Document) ReferencedLCTIDs() []string {
+	seen := map[string]bool{doc.LCTID: true}
+	var ids []string
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	add(doc.BirthCert.IssuingSociety)
+	add(doc.BirthCert.CitizenRole)
+	add(doc.BirthCert.ParentEntity)
+	for _, w := range doc.BirthCert.BirthWitnesses {
+		add(w)
+	}
+	for _, b := range doc.MRH.Bound {
+		add(b.LCTID)
+	}
+	for _, p := range doc.MRH.Paired {
+		add(p.LCTID)
+	}
+	for _, w := range doc.MRH.Witnessing {
+		add(w.LCTID)
+	}
+	for _, l := range doc.Lineage {
+		if l.Reason != LineageRotation {
+			add(l.Parent)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Graph Export
+// ═══════════════════════════════════════════════════════════════
+
+// ToDOT renders the document's MRH as a Graphviz DOT digraph. The document
+// is the center node, with edges to bound (solid), paired (dashed), and
+// witnessing (dotted) entities labeled by bound type, pairing type, or
+// witness role. Roles are drawn as diamonds, societies as double circles,
+// and everything else as ellipses, based on the LCT ID's type segment.
+func (doc *Document) ToDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph mrh {\n")
+	fmt.Fprintf(&b, "  %q [shape=box, style=bold];\n", doc.LCTID)
+
+	seen := map[string]bool{doc.LCTID: true}
+	node := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			fmt.Fprintf(&b, "  %q [shape=%s];\n", id, dotShape(id))
+		}
+	}
+	edge := func(id, style, label string) {
+		node(id)
+		fmt.Fprintf(&b, "  %q -> %q [style=%s, label=%q];\n", doc.LCTID, id, style, label)
+	}
+
+	for _, e := range doc.MRH.Bound {
+		edge(e.LCTID, "solid", string(e.Type))
+	}
+	for _, e := range doc.MRH.Paired {
+		edge(e.LCTID, "dashed", string(e.PairingType))
+	}
+	for _, e := range doc.MRH.Witnessing {
+		edge(e.LCTID, "dotted", string(e.Role))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotShape picks a node shape from the entity type segment of an LCT ID
+// ("lct:<namespace>:<type>:...").
+func dotShape(lctID string) string {
+	parts := strings.SplitN(lctID, ":", 4)
+	if len(parts) < 3 {
+		return "ellipse"
+	}
+	switch EntityType(parts[2]) {
+	case EntityRole:
+		return "diamond"
+	case EntitySociety:
+		return "doublecircle"
+	}
+	return "ellipse"
+}
@@ -0,0 +1,377 @@
+package lct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// MRH Mutation Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestUpsertPairedInsert(t *testing.T) {
+	doc := minimalValidDoc()
+	err := doc.MRH.UpsertPaired(MRHPaired{
+		LCTID:       "lct:web4:service:telemetry",
+		PairingType: PairingOperational,
+	})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if len(doc.MRH.Paired) != 2 {
+		t.Fatalf("Expected 2 pairings, got %d", len(doc.MRH.Paired))
+	}
+	if doc.MRH.Paired[1].TS == "" {
+		t.Error("Upsert should default the pairing timestamp")
+	}
+	if doc.MRH.LastUpdated == "2026-02-19T00:00:00Z" {
+		t.Error("Upsert should refresh LastUpdated")
+	}
+}
+
+func TestUpsertPairedUpdate(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Paired = append(doc.MRH.Paired, MRHPaired{
+		LCTID:       "lct:web4:role:worker",
+		PairingType: PairingRole,
+		TS:          "2026-02-19T00:00:00Z",
+	})
+
+	err := doc.MRH.UpsertPaired(MRHPaired{
+		LCTID:       "lct:web4:role:worker",
+		PairingType: PairingOperational,
+		Context:     "reassigned",
+	})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if len(doc.MRH.Paired) != 2 {
+		t.Fatalf("Expected update in place (2 pairings), got %d", len(doc.MRH.Paired))
+	}
+	if doc.MRH.Paired[1].PairingType != PairingOperational || doc.MRH.Paired[1].Context != "reassigned" {
+		t.Errorf("Pairing not replaced: %+v", doc.MRH.Paired[1])
+	}
+}
+
+func TestUpsertPairedPermanentConflict(t *testing.T) {
+	doc := minimalValidDoc()
+	err := doc.MRH.UpsertPaired(MRHPaired{
+		LCTID:       "lct:web4:role:citizen:ai",
+		PairingType: PairingOperational,
+	})
+	if err == nil {
+		t.Fatal("Expected error overriding permanent citizen pairing")
+	}
+	if doc.MRH.Paired[0].PairingType != PairingBirthCertificate || !doc.MRH.Paired[0].Permanent {
+		t.Error("Permanent citizen pairing should be unchanged")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Canonical Ordering Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestCanonicalizeReorderedPairingsHashEqual(t *testing.T) {
+	extra := []MRHPaired{
+		{LCTID: "lct:web4:service:b", PairingType: PairingOperational, TS: "2026-02-19T00:00:00Z"},
+		{LCTID: "lct:web4:service:a", PairingType: PairingOperational, TS: "2026-02-19T00:00:00Z"},
+	}
+	doc1 := minimalValidDoc()
+	doc1.MRH.Paired = append(doc1.MRH.Paired, extra[0], extra[1])
+	doc2 := minimalValidDoc()
+	doc2.MRH.Paired = append([]MRHPaired{extra[1], extra[0]}, doc2.MRH.Paired...)
+
+	doc1.Canonicalize()
+	doc2.Canonicalize()
+	if doc1.Hash() != doc2.Hash() {
+		t.Error("Reordered pairings should hash equally after canonicalization")
+	}
+	if doc1.MRH.Paired[0].LCTID != "lct:web4:role:citizen:ai" || doc1.MRH.Paired[2].LCTID != "lct:web4:service:b" {
+		t.Errorf("Expected pairings sorted by LCTID, got %+v", doc1.MRH.Paired)
+	}
+}
+
+func TestHashIgnoresMRHOrderWithoutMutation(t *testing.T) {
+	doc1 := minimalValidDoc()
+	doc1.MRH.Witnessing = []MRHWitnessing{
+		{LCTID: "lct:web4:oracle:z", Role: WitnessTime},
+		{LCTID: "lct:web4:oracle:a", Role: WitnessAudit},
+	}
+	doc2 := minimalValidDoc()
+	doc2.MRH.Witnessing = []MRHWitnessing{doc1.MRH.Witnessing[1], doc1.MRH.Witnessing[0]}
+
+	if doc1.Hash() != doc2.Hash() {
+		t.Error("Hash should canonicalize MRH ordering")
+	}
+	if doc1.MRH.Witnessing[0].LCTID != "lct:web4:oracle:z" {
+		t.Error("Hash should not reorder the receiver's slices")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Revocation Propagation Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestPropagateRevocation(t *testing.T) {
+	revoked := minimalValidDoc()
+	revoked.Revocation = &Revocation{Status: RevocationRevoked, Reason: RevocationCompromise}
+
+	pairedBack := func(id string, pairingType PairingType, permanent bool) *Document {
+		doc := minimalValidDoc()
+		doc.LCTID = id
+		doc.MRH.Paired = append(doc.MRH.Paired, MRHPaired{LCTID: revoked.LCTID, PairingType: pairingType, Permanent: permanent})
+		revoked.MRH.Paired = append(revoked.MRH.Paired, MRHPaired{LCTID: id, PairingType: pairingType, Permanent: permanent})
+		return doc
+	}
+	telemetry := pairedBack("lct:web4:service:telemetry", PairingOperational, false)
+	worker := pairedBack("lct:web4:role:worker", PairingRole, false)
+	society := pairedBack("lct:web4:society:genesis", PairingBirthCertificate, true)
+	oneSided := minimalValidDoc()
+	oneSided.LCTID = "lct:web4:service:forgot"
+	revoked.MRH.Paired = append(revoked.MRH.Paired, MRHPaired{LCTID: oneSided.LCTID, PairingType: PairingOperational})
+
+	resolver := NewMapResolver(telemetry, worker, society, oneSided)
+	// The revoked doc's own citizen pairing is permanent; it must never be resolved
+	var applied []string
+	err := PropagateRevocation(revoked, resolver, func(doc *Document, status PairingStatus) error {
+		if status != PairingRevoked {
+			t.Errorf("Expected PairingRevoked, got %q", status)
+		}
+		applied = append(applied, doc.LCTID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("PropagateRevocation failed: %v", err)
+	}
+	if len(applied) != 2 || applied[0] != telemetry.LCTID || applied[1] != worker.LCTID {
+		t.Errorf("Expected callbacks for telemetry and worker only, got %v", applied)
+	}
+}
+
+func TestPropagateRevocationErrors(t *testing.T) {
+	active := minimalValidDoc()
+	if err := PropagateRevocation(active, MapResolver{}, nil); err == nil {
+		t.Error("Expected error propagating an active document")
+	}
+
+	revoked := minimalValidDoc()
+	revoked.Revocation = &Revocation{Status: RevocationRevoked}
+	revoked.MRH.Paired = append(revoked.MRH.Paired, MRHPaired{LCTID: "lct:web4:service:missing", PairingType: PairingOperational})
+	err := PropagateRevocation(revoked, MapResolver{}, func(*Document, PairingStatus) error { return nil })
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected unresolved partner to surface ErrNotFound, got %v", err)
+	}
+}
+
+func TestPropagateRevocationContextCanceled(t *testing.T) {
+	revoked := minimalValidDoc()
+	revoked.Revocation = &Revocation{Status: RevocationRevoked}
+	revoked.MRH.Paired = append(revoked.MRH.Paired, MRHPaired{LCTID: "lct:web4:service:telemetry", PairingType: PairingOperational})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	applied := 0
+	err := PropagateRevocationContext(ctx, revoked, MapResolver{}, func(*Document, PairingStatus) error {
+		applied++
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || applied != 0 {
+		t.Errorf("Expected cancellation before any callback, got %v after %d callbacks", err, applied)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Horizon Traversal Tests
+// ═══════════════════════════════════════════════════════════════
+
+// chainDoc returns a document named id bound to parent (if non-empty).
+func chainDoc(id, parent string) *Document {
+	doc := minimalValidDoc()
+	doc.LCTID = id
+	doc.MRH.Paired = nil
+	if parent != "" {
+		doc.MRH.Bound = []MRHBound{{LCTID: parent, Type: BoundParent}}
+	}
+	return doc
+}
+
+func TestHorizonEntitiesDepthLimit(t *testing.T) {
+	// root → d1 → d2 → d3 → d4, with a back edge d2 → root
+	d4 := chainDoc("lct:web4:ai:d4", "")
+	d3 := chainDoc("lct:web4:ai:d3", d4.LCTID)
+	d2 := chainDoc("lct:web4:ai:d2", d3.LCTID)
+	d2.MRH.Paired = []MRHPaired{{LCTID: "lct:web4:ai:root", PairingType: PairingOperational}}
+	d1 := chainDoc("lct:web4:ai:d1", d2.LCTID)
+	root := chainDoc("lct:web4:ai:root", d1.LCTID)
+	root.MRH.HorizonDepth = 3
+
+	ids, err := root.HorizonEntities(NewMapResolver(root, d1, d2, d3, d4))
+	if err != nil {
+		t.Fatalf("HorizonEntities failed: %v", err)
+	}
+	assertEqual(t, "horizon", "lct:web4:ai:d1,lct:web4:ai:d2,lct:web4:ai:d3", strings.Join(ids, ","))
+}
+
+func TestHorizonEntitiesUnresolved(t *testing.T) {
+	doc := minimalValidDoc() // paired to an unresolvable citizen role
+	doc.MRH.Bound = []MRHBound{{LCTID: "lct:web4:society:genesis", Type: BoundParent}}
+	society := chainDoc("lct:web4:society:genesis", "")
+	society.MRH.Paired = []MRHPaired{{LCTID: "lct:web4:ai:peer", PairingType: PairingOperational}}
+
+	ids, err := doc.HorizonEntities(NewMapResolver(society))
+	if err != nil {
+		t.Fatalf("Unresolved references should not fail traversal: %v", err)
+	}
+	assertEqual(t, "horizon", "lct:web4:society:genesis,lct:web4:role:citizen:ai,lct:web4:ai:peer", strings.Join(ids, ","))
+}
+
+func TestEffectiveHorizonDepth(t *testing.T) {
+	// root → d1 → d2 → d3, with a back edge d3 → root
+	d3 := chainDoc("lct:web4:ai:d3", "lct:web4:ai:root")
+	d2 := chainDoc("lct:web4:ai:d2", d3.LCTID)
+	d1 := chainDoc("lct:web4:ai:d1", d2.LCTID)
+	root := chainDoc("lct:web4:ai:root", d1.LCTID)
+
+	depth, err := root.EffectiveHorizonDepth(NewMapResolver(root, d1, d2, d3))
+	if err != nil {
+		t.Fatalf("EffectiveHorizonDepth failed: %v", err)
+	}
+	if depth != 3 {
+		t.Errorf("Expected effective depth 3, got %d", depth)
+	}
+}
+
+func TestEffectiveHorizonDepthBeyondDeclared(t *testing.T) {
+	// root → d1 → ... → d5, with an unresolvable leaf past d5
+	docs := []*Document{chainDoc("lct:web4:ai:d5", "lct:web4:ai:missing")}
+	for i := 4; i >= 1; i-- {
+		docs = append(docs, chainDoc(fmt.Sprintf("lct:web4:ai:d%d", i), docs[len(docs)-1].LCTID))
+	}
+	root := chainDoc("lct:web4:ai:root", docs[len(docs)-1].LCTID)
+	root.MRH.HorizonDepth = 2
+
+	depth, err := root.EffectiveHorizonDepth(NewMapResolver(append(docs, root)...))
+	if err != nil {
+		t.Fatalf("EffectiveHorizonDepth failed: %v", err)
+	}
+	if depth != 6 || depth <= root.MRH.HorizonDepth {
+		t.Errorf("Expected effective depth 6 beyond declared 2, got %d", depth)
+	}
+
+	if depth, _ := chainDoc("lct:web4:ai:alone", "").EffectiveHorizonDepth(MapResolver{}); depth != 0 {
+		t.Errorf("Expected depth 0 for an isolated document, got %d", depth)
+	}
+}
+
+func TestFindTrustPath(t *testing.T) {
+	// alice → society ← bob (bob bound to society), bob witnessed by oracle
+	society := chainDoc("lct:web4:society:genesis", "")
+	alice := chainDoc("lct:web4:ai:alice", society.LCTID)
+	bob := chainDoc("lct:web4:ai:bob", "")
+	bob.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:time", Role: WitnessTime}}
+	society.MRH.Paired = []MRHPaired{{LCTID: bob.LCTID, PairingType: PairingOperational}}
+	loner := chainDoc("lct:web4:ai:loner", "")
+	resolver := NewMapResolver(society, alice, bob, loner)
+
+	path, err := FindTrustPath(alice.LCTID, bob.LCTID, resolver, 3)
+	if err != nil {
+		t.Fatalf("FindTrustPath failed: %v", err)
+	}
+	assertEqual(t, "path", "lct:web4:ai:alice,lct:web4:society:genesis,lct:web4:ai:bob", strings.Join(path, ","))
+
+	path, err = FindTrustPath(alice.LCTID, "lct:web4:oracle:time", resolver, 3)
+	if err != nil || len(path) != 4 {
+		t.Errorf("Expected a three-hop path via a witnessing edge, got %v (%v)", path, err)
+	}
+	if _, err := FindTrustPath(alice.LCTID, "lct:web4:oracle:time", resolver, 2); !errors.Is(err, ErrNoRelationship) {
+		t.Errorf("Expected ErrNoRelationship beyond maxDepth, got %v", err)
+	}
+}
+
+func TestHorizonTraversalHonorsCancellation(t *testing.T) {
+	d2 := chainDoc("lct:web4:ai:d2", "")
+	d1 := chainDoc("lct:web4:ai:d1", d2.LCTID)
+	root := chainDoc("lct:web4:ai:root", d1.LCTID)
+	resolver := NewMapResolver(root, d1, d2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := root.HorizonEntitiesContext(ctx, resolver); !errors.Is(err, context.Canceled) {
+		t.Errorf("HorizonEntities: expected context.Canceled, got %v", err)
+	}
+	if _, err := root.EffectiveHorizonDepthContext(ctx, resolver); !errors.Is(err, context.Canceled) {
+		t.Errorf("EffectiveHorizonDepth: expected context.Canceled, got %v", err)
+	}
+	if _, err := FindTrustPathContext(ctx, root.LCTID, d2.LCTID, resolver, 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindTrustPath: expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFindTrustPathDisconnected(t *testing.T) {
+	a := chainDoc("lct:web4:ai:a", "lct:web4:ai:b")
+	b := chainDoc("lct:web4:ai:b", "lct:web4:ai:a")
+	island := chainDoc("lct:web4:ai:island", "")
+
+	_, err := FindTrustPath(a.LCTID, island.LCTID, NewMapResolver(a, b, island), 10)
+	if !errors.Is(err, ErrNoRelationship) {
+		t.Errorf("Expected ErrNoRelationship for a disconnected pair, got %v", err)
+	}
+	if _, err := FindTrustPath("lct:web4:ai:ghost", a.LCTID, NewMapResolver(a), 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unresolvable origin, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Reference Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestReferencedLCTIDs(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Lineage = []LineageEntry{{Parent: "mb64oldkey", Reason: LineageRotation}, {Parent: "lct:web4:ai:ancestor", Reason: LineageGenesis}}
+
+	assertEqual(t, "referenced", strings.Join([]string{
+		"lct:web4:ai:ancestor",
+		"lct:web4:role:citizen:ai",
+		"lct:web4:society:genesis",
+		"lct:web4:witness:w1",
+		"lct:web4:witness:w2",
+		"lct:web4:witness:w3",
+	}, ","), strings.Join(doc.ReferencedLCTIDs(), ","))
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Graph Export Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestToDOT(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Bound = []MRHBound{{LCTID: "lct:web4:society:genesis", Type: BoundParent}}
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:time", Role: WitnessTime}}
+	dot := doc.ToDOT()
+
+	for _, want := range []string{
+		`"lct:web4:ai:test0000deadbeef" -> "lct:web4:society:genesis" [style=solid, label="parent"];`,
+		`"lct:web4:ai:test0000deadbeef" -> "lct:web4:role:citizen:ai" [style=dashed, label="birth_certificate"];`,
+		`"lct:web4:ai:test0000deadbeef" -> "lct:web4:oracle:time" [style=dotted, label="time"];`,
+		`"lct:web4:society:genesis" [shape=doublecircle];`,
+		`"lct:web4:role:citizen:ai" [shape=diamond];`,
+		`"lct:web4:oracle:time" [shape=ellipse];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %s\n%s", want, dot)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(dot), "\n")
+	if lines[0] != "digraph mrh {" || lines[len(lines)-1] != "}" {
+		t.Fatalf("Expected digraph block, got:\n%s", dot)
+	}
+	for _, l := range lines[1 : len(lines)-1] {
+		if !strings.HasSuffix(l, ";") || strings.Count(l, `"`)%2 != 0 || strings.Count(l, "[") != strings.Count(l, "]") {
+			t.Errorf("Malformed DOT statement: %s", l)
+		}
+	}
+}
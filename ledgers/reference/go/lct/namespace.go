@@ -0,0 +1,63 @@
+package lct
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// DefaultNamespace is the LCT ID namespace used when none is configured:
+// LCT IDs take the form "lct:{namespace}:{entity_type}:{hash}".
+const DefaultNamespace = "web4"
+
+var (
+	// Namespace validation (lowercase alphanumeric with hyphens)
+	namespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// Any-namespace LCT ID, for references that may cross namespaces
+	anyLCTIDPattern = regexp.MustCompile(`^lct:[a-z0-9][a-z0-9-]*:[A-Za-z0-9_:-]+$`)
+
+	namespaceMu      sync.RWMutex
+	currentNamespace = DefaultNamespace
+
+	// Compiled lct_id patterns by namespace
+	lctIDPatterns sync.Map
+)
+
+// ValidateNamespace checks that ns is safe to embed in LCT IDs.
+func ValidateNamespace(ns string) error {
+	if !namespacePattern.MatchString(ns) {
+		return fmt.Errorf("invalid namespace %q - must be lowercase alphanumeric with hyphens", ns)
+	}
+	return nil
+}
+
+// Namespace returns the package-wide default LCT ID namespace.
+func Namespace() string {
+	namespaceMu.RLock()
+	defer namespaceMu.RUnlock()
+	return currentNamespace
+}
+
+// SetNamespace changes the package-wide default LCT ID namespace used by
+// NewBuilder and ValidateDocument. Private deployments set this once at
+// startup (e.g. "acme" for "lct:acme:..." IDs).
+func SetNamespace(ns string) error {
+	if err := ValidateNamespace(ns); err != nil {
+		return err
+	}
+	namespaceMu.Lock()
+	currentNamespace = ns
+	namespaceMu.Unlock()
+	return nil
+}
+
+// lctIDPatternFor returns the lct_id pattern for namespace ns.
+func lctIDPatternFor(ns string) *regexp.Regexp {
+	if p, ok := lctIDPatterns.Load(ns); ok {
+		return p.(*regexp.Regexp)
+	}
+	p := regexp.MustCompile(`^lct:` + regexp.QuoteMeta(ns) + `:[A-Za-z0-9_:-]+$`)
+	lctIDPatterns.Store(ns, p)
+	return p
+}
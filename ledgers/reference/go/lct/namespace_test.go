@@ -0,0 +1,70 @@
+package lct
+
+import (
+	"strings"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Namespace Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestBuilderWithNamespace(t *testing.T) {
+	doc, err := NewBuilder(EntityAI, "private-agent").
+		WithNamespace("acme").
+		WithBinding("mb64key", "cose:proof").
+		WithBirthCertificate(
+			"lct:acme:society:hq",
+			"lct:acme:role:citizen:ai",
+			BirthOrganization,
+			[]string{"lct:acme:witness:w1", "lct:acme:witness:w2", "lct:acme:witness:w3"},
+		).
+		AddCapability("witness:attest").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !strings.HasPrefix(doc.LCTID, "lct:acme:ai:") {
+		t.Errorf("Expected lct:acme:ai: prefix, got %q", doc.LCTID)
+	}
+
+	if ValidateDocument(doc).Valid {
+		t.Error("Default-namespace validation should reject an acme LCT ID")
+	}
+	if result := ValidateDocumentWithOptions(doc, ValidateOptions{Namespace: "acme"}); !result.Valid {
+		t.Errorf("Expected valid under acme namespace, got: %v", result.Errors)
+	}
+}
+
+func TestBuilderWithInvalidNamespace(t *testing.T) {
+	_, err := NewBuilder(EntityAI, "agent").
+		WithNamespace("Bad:NS").
+		WithBinding("mb64key", "cose:proof").
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "namespace") {
+		t.Errorf("Expected namespace error, got %v", err)
+	}
+}
+
+func TestSetNamespace(t *testing.T) {
+	defer SetNamespace(DefaultNamespace)
+
+	if err := SetNamespace("lct:evil"); err == nil {
+		t.Error("Expected unsafe namespace to be rejected")
+	}
+	assertEqual(t, "namespace", DefaultNamespace, Namespace())
+
+	if err := SetNamespace("acme"); err != nil {
+		t.Fatalf("SetNamespace failed: %v", err)
+	}
+	doc := NewBuilder(EntityHuman, "alice").BuildUnsafe()
+	if !strings.HasPrefix(doc.LCTID, "lct:acme:human:") {
+		t.Errorf("Expected builder to use configured namespace, got %q", doc.LCTID)
+	}
+
+	valid := minimalValidDoc()
+	valid.LCTID = "lct:acme:ai:test0000deadbeef"
+	if result := ValidateDocument(valid); !result.Valid {
+		t.Errorf("Expected acme ID valid under configured namespace, got: %v", result.Errors)
+	}
+}
@@ -0,0 +1,510 @@
+package lct
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Constraint Evaluation
+// ═══════════════════════════════════════════════════════════════
+
+// Well-known Policy.Constraints keys evaluated by EvaluateConstraints, and
+// the runtime context key each is checked against:
+//
+//	max_value        (number)   ctx["value"]   (number)    value <= max_value
+//	allowed_networks ([]string) ctx["network"] (string)    network in list
+//	expires_at       (RFC3339)  ctx["now"]     (time.Time) now < expires_at; defaults to Now()
+//	min_trust        (number)   ctx["trust"]   (number)    trust >= min_trust
+const (
+	ConstraintMaxValue        = "max_value"
+	ConstraintAllowedNetworks = "allowed_networks"
+	ConstraintExpiresAt       = "expires_at"
+	ConstraintMinTrust        = "min_trust"
+)
+
+// entityConstraintKeys are Policy.Constraints keys interpreted by
+// entity-type and capability helpers rather than evaluated as runtime
+// constraints. EvaluateConstraints skips them without reporting.
+var entityConstraintKeys = map[string]bool{
+	ConstraintTerms:      true,
+	ConstraintTaskStatus: true,
+	ConstraintSLA:        true,
+	ConstraintNamespace:  true,
+
+	ConstraintCapabilityExpiry: true,
+	ConstraintReadScope:        true,
+	ConstraintWriteScope:       true,
+}
+
+// EvaluateConstraints checks the policy's well-known constraints against a
+// runtime context. It returns whether all constraints passed and a message
+// for each failed constraint. Unknown constraint keys are reported in the
+// messages but do not fail evaluation. A constraint whose context input is
+// missing fails closed. The error is non-nil only for malformed constraint
+// values.
+func (p *Policy) EvaluateConstraints(ctx map[string]interface{}) (bool, []string, error) {
+	keys := make([]string, 0, len(p.Constraints))
+	for k := range p.Constraints {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ok := true
+	var messages []string
+	fail := func(format string, args ...interface{}) {
+		ok = false
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+
+	for _, key := range keys {
+		raw := p.Constraints[key]
+		switch key {
+		case ConstraintMaxValue:
+			max, isNum := toFloat(raw)
+			if !isNum {
+				return false, messages, fmt.Errorf("constraint %s: expected number, got %T", key, raw)
+			}
+			value, has := toFloat(ctx["value"])
+			if !has {
+				fail("%s: context has no numeric value", key)
+			} else if value > max {
+				fail("%s: value %g exceeds maximum %g", key, value, max)
+			}
+
+		case ConstraintAllowedNetworks:
+			networks, isList := toStringSlice(raw)
+			if !isList {
+				return false, messages, fmt.Errorf("constraint %s: expected list of strings, got %T", key, raw)
+			}
+			network, _ := ctx["network"].(string)
+			if network == "" {
+				fail("%s: context has no network", key)
+			} else if !containsString(networks, network) {
+				fail("%s: network %q not in %v", key, network, networks)
+			}
+
+		case ConstraintExpiresAt:
+			s, isStr := raw.(string)
+			expires, err := time.Parse(time.RFC3339, s)
+			if !isStr || err != nil {
+				return false, messages, fmt.Errorf("constraint %s: expected RFC3339 timestamp, got %v", key, raw)
+			}
+			now, has := ctx["now"].(time.Time)
+			if !has {
+				now = Now()
+			}
+			if !now.Before(expires) {
+				fail("%s: policy expired at %s", key, s)
+			}
+
+		case ConstraintMinTrust:
+			min, isNum := toFloat(raw)
+			if !isNum {
+				return false, messages, fmt.Errorf("constraint %s: expected number, got %T", key, raw)
+			}
+			trust, has := toFloat(ctx["trust"])
+			if !has {
+				fail("%s: context has no numeric trust", key)
+			} else if trust < min {
+				fail("%s: trust %g below minimum %g", key, trust, min)
+			}
+
+		default:
+			if entityConstraintKeys[key] {
+				continue
+			}
+			messages = append(messages, fmt.Sprintf("%s: unknown constraint (not evaluated)", key))
+		}
+	}
+	return ok, messages, nil
+}
+
+// toFloat converts JSON-decoded and native numeric values to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// toStringSlice converts []string and JSON-decoded []interface{} values.
+func toStringSlice(v interface{}) ([]string, bool) {
+	switch s := v.(type) {
+	case []string:
+		return s, true
+	case []interface{}:
+		out := make([]string, 0, len(s))
+		for _, item := range s {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, str)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Conflicts
+// ═══════════════════════════════════════════════════════════════
+
+// CapabilityConflict declares two capability patterns that contradict
+// each other when both are granted. A pattern is an exact capability,
+// a "verb:*" prefix wildcard, or "*" for any capability.
+type CapabilityConflict struct {
+	A, B   string
+	Reason string
+}
+
+// CapabilityConflictTable is the conflict table consulted by
+// Policy.Conflicts. Deployments may replace or extend it.
+var CapabilityConflictTable = []CapabilityConflict{
+	{A: "deny:all", B: "*", Reason: "deny:all revokes every other capability"},
+	{A: "deny:write", B: "write:*", Reason: "deny:write revokes write capabilities"},
+	{A: "deny:read", B: "read:*", Reason: "deny:read revokes read capabilities"},
+	{A: "deny:admin", B: "admin:*", Reason: "deny:admin revokes admin capabilities"},
+}
+
+// Conflicts returns human-readable descriptions of contradictory
+// capability pairs (per CapabilityConflictTable) and redundant overlaps
+// where a "verb:*" wildcard already covers a listed capability.
+func (p *Policy) Conflicts() []string {
+	var conflicts []string
+	caps := p.Capabilities
+
+	for _, rule := range CapabilityConflictTable {
+		for _, a := range caps {
+			if !matchCapability(rule.A, a) {
+				continue
+			}
+			for _, b := range caps {
+				if a != b && matchCapability(rule.B, b) {
+					conflicts = append(conflicts, fmt.Sprintf("%s conflicts with %s: %s", a, b, rule.Reason))
+				}
+			}
+		}
+	}
+
+	for _, wildcard := range caps {
+		if wildcard == "*" || !strings.HasSuffix(wildcard, ":*") {
+			continue
+		}
+		for _, c := range caps {
+			if c != wildcard && matchCapability(wildcard, c) {
+				conflicts = append(conflicts, fmt.Sprintf("%s is redundant with %s", c, wildcard))
+			}
+		}
+	}
+	return conflicts
+}
+
+// matchCapability reports whether capability c matches pattern: "*"
+// matches anything, "prefix:*" matches capabilities starting "prefix:",
+// and any other pattern must match exactly.
+func matchCapability(pattern, c string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, ":*") {
+		return strings.HasPrefix(c, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == c
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Grammar
+// ═══════════════════════════════════════════════════════════════
+
+// ValidateCapability checks c against the verb:resource[:scope] grammar:
+// a lowercase verb (letters, digits, '_' or '-', starting with a letter)
+// followed by a non-empty resource and optional non-empty scope. The
+// resource or scope may be the wildcard "*" (e.g. "read:*", "mcp:files:*"),
+// and a bare "*" is accepted as the grant-all capability honoured by
+// Policy.Authorizes.
+func ValidateCapability(c string) error {
+	if c == "*" {
+		return nil
+	}
+	verb, rest, ok := strings.Cut(c, ":")
+	if !ok {
+		return fmt.Errorf("capability %q must have the form verb:resource[:scope]", c)
+	}
+	if !isCapabilityVerb(verb) {
+		return fmt.Errorf("capability %q has invalid verb %q: must be lowercase", c, verb)
+	}
+	resource, scope, hasScope := strings.Cut(rest, ":")
+	if resource == "" {
+		return fmt.Errorf("capability %q has an empty resource", c)
+	}
+	if hasScope && scope == "" {
+		return fmt.Errorf("capability %q has an empty scope", c)
+	}
+	if strings.Contains(scope, ":") {
+		return fmt.Errorf("capability %q has more than three segments", c)
+	}
+	for _, seg := range [2]string{resource, scope} {
+		if seg != "*" && strings.ContainsAny(seg, "* \t\n") {
+			return fmt.Errorf("capability %q has invalid segment %q", c, seg)
+		}
+	}
+	return nil
+}
+
+func isCapabilityVerb(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		ch := s[i]
+		if (ch < 'a' || ch > 'z') && (ch < '0' || ch > '9') && ch != '_' && ch != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Risk
+// ═══════════════════════════════════════════════════════════════
+
+// CapabilityRisk assigns a minimum trust threshold to capabilities
+// matching Pattern (same pattern syntax as CapabilityConflict).
+type CapabilityRisk struct {
+	Pattern   string
+	Threshold float64
+}
+
+// CapabilityRiskTable is consulted by RecommendTrustThreshold; the first
+// matching entry applies to each capability. Deployments may replace or
+// extend it.
+var CapabilityRiskTable = []CapabilityRisk{
+	{Pattern: "admin:*", Threshold: 0.9},
+	{Pattern: "write:*", Threshold: 0.7},
+	{Pattern: "execute:*", Threshold: 0.7},
+	{Pattern: "witness:*", Threshold: 0.5},
+	{Pattern: "read:*", Threshold: 0.3},
+}
+
+// DefaultCapabilityThreshold is the threshold recommended for capabilities
+// not matched by CapabilityRiskTable.
+var DefaultCapabilityThreshold = 0.5
+
+// RecommendTrustThreshold suggests the minimum T3 trust threshold for an
+// entity requesting capabilities: the highest threshold any of them
+// requires. An empty list recommends 0.
+func RecommendTrustThreshold(capabilities []string) float64 {
+	threshold := 0.0
+	for _, c := range capabilities {
+		t := DefaultCapabilityThreshold
+		for _, risk := range CapabilityRiskTable {
+			if matchCapability(risk.Pattern, c) {
+				t = risk.Threshold
+				break
+			}
+		}
+		threshold = math.Max(threshold, t)
+	}
+	return threshold
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Expiry
+// ═══════════════════════════════════════════════════════════════
+
+// ConstraintCapabilityExpiry is the Policy.Constraints key holding a map
+// of capability to RFC3339 expiry. Capabilities absent from the map never
+// expire; re-attesting a capability means writing a later expiry.
+const ConstraintCapabilityExpiry = "capability_expiry"
+
+// ActiveCapabilities returns the policy capabilities that have not expired
+// at now, in policy order.
+func (doc *Document) ActiveCapabilities(now time.Time) []string {
+	active, _ := doc.partitionCapabilities(now)
+	return active
+}
+
+// ExpiredCapabilities returns the policy capabilities whose expiry is at
+// or before now. An unparseable expiry counts as expired.
+func (doc *Document) ExpiredCapabilities(now time.Time) []string {
+	_, expired := doc.partitionCapabilities(now)
+	return expired
+}
+
+func (doc *Document) partitionCapabilities(now time.Time) (active, expired []string) {
+	expiry, _ := toStringMap(doc.Policy.Constraints[ConstraintCapabilityExpiry])
+	for _, c := range doc.Policy.Capabilities {
+		s, ok := expiry[c]
+		if !ok {
+			active = append(active, c)
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil && now.Before(t) {
+			active = append(active, c)
+		} else {
+			expired = append(expired, c)
+		}
+	}
+	return active, expired
+}
+
+// toStringMap converts map[string]string and JSON-decoded
+// map[string]interface{} values; non-string entries are kept as "" so
+// they fail timestamp parsing.
+func toStringMap(v interface{}) (map[string]string, bool) {
+	switch m := v.(type) {
+	case map[string]string:
+		return m, true
+	case map[string]interface{}:
+		out := make(map[string]string, len(m))
+		for k, item := range m {
+			out[k], _ = item.(string)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Policy Merging
+// ═══════════════════════════════════════════════════════════════
+
+// MergePolicies layers policies into one effective policy, e.g. society,
+// then role, then citizen. Capabilities are unioned, deduplicated, and
+// sorted. Constraints merge per key with the last policy that sets a key
+// winning.
+//
+// Conflict rule: constraint values are never combined. When layers set
+// the same key to incompatible values, whether a different number, a
+// different type, or a nested map with other keys, the later layer's
+// value replaces the earlier one wholesale. Order policies from general
+// to specific so the most specific layer decides.
+//
+// The result shares no maps or slices with the inputs.
+func MergePolicies(policies ...Policy) Policy {
+	merged := Policy{Capabilities: []string{}}
+	for _, p := range policies {
+		for _, c := range p.Capabilities {
+			if !containsString(merged.Capabilities, c) {
+				merged.Capabilities = append(merged.Capabilities, c)
+			}
+		}
+		for k, v := range p.Constraints {
+			if merged.Constraints == nil {
+				merged.Constraints = map[string]interface{}{}
+			}
+			merged.Constraints[k] = cloneValue(v)
+		}
+	}
+	sort.Strings(merged.Capabilities)
+	return merged
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Implied Capabilities
+// ═══════════════════════════════════════════════════════════════
+
+// Scope constraints whose presence grants capabilities (see
+// CapabilityImplicationTable).
+const (
+	ConstraintReadScope  = "read_scope"
+	ConstraintWriteScope = "write_scope"
+)
+
+// CapabilityImplication grants Capabilities to any policy that sets
+// Constraint to a non-empty value.
+type CapabilityImplication struct {
+	Constraint   string
+	Capabilities []string
+}
+
+// CapabilityImplicationTable is the constraint→capability derivation
+// consulted by ImpliedCapabilities. Deployments may replace or extend it.
+//
+//	read_scope  → read:*
+//	write_scope → write:*
+var CapabilityImplicationTable = []CapabilityImplication{
+	{Constraint: ConstraintReadScope, Capabilities: []string{"read:*"}},
+	{Constraint: ConstraintWriteScope, Capabilities: []string{"write:*"}},
+}
+
+// ImpliedCapabilities returns the capabilities granted by the policy's
+// constraints per CapabilityImplicationTable, deduplicated and sorted.
+// A constraint set to nil, false, or an empty string or list grants
+// nothing.
+func (p *Policy) ImpliedCapabilities() []string {
+	var implied []string
+	for _, rule := range CapabilityImplicationTable {
+		if isEmptyConstraint(p.Constraints[rule.Constraint]) {
+			continue
+		}
+		for _, c := range rule.Capabilities {
+			if !containsString(implied, c) {
+				implied = append(implied, c)
+			}
+		}
+	}
+	sort.Strings(implied)
+	return implied
+}
+
+func isEmptyConstraint(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !t
+	case string:
+		return t == ""
+	}
+	if list, ok := toStringSlice(v); ok {
+		return len(list) == 0
+	}
+	return false
+}
+
+// Authorizes reports whether the policy grants capability, through an
+// explicit or implied capability pattern (same syntax as
+// CapabilityConflict). An explicit deny capability from
+// CapabilityConflictTable overrides any grant it conflicts with.
+func (p *Policy) Authorizes(capability string) bool {
+	for _, rule := range CapabilityConflictTable {
+		if rule.A == capability || !matchCapability(rule.B, capability) {
+			continue
+		}
+		for _, c := range p.Capabilities {
+			if matchCapability(rule.A, c) {
+				return false
+			}
+		}
+	}
+	for _, granted := range [][]string{p.Capabilities, p.ImpliedCapabilities()} {
+		for _, c := range granted {
+			if matchCapability(c, capability) {
+				return true
+			}
+		}
+	}
+	return false
+}
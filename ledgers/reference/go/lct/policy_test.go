@@ -0,0 +1,355 @@
+package lct
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Constraint Evaluation Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestEvaluateConstraintsPass(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{
+		"max_value":        100.0,
+		"allowed_networks": []string{"testnet", "mainnet"},
+		"min_trust":        0.6,
+	}}
+	ok, failed, err := p.EvaluateConstraints(map[string]interface{}{
+		"value":   50,
+		"network": "testnet",
+		"trust":   0.8,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("Expected all constraints to pass, failed: %v", failed)
+	}
+}
+
+func TestEvaluateConstraintsMaxValueBreach(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{"max_value": 100.0}}
+	ok, failed, err := p.EvaluateConstraints(map[string]interface{}{"value": 150.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("Expected max_value breach to fail")
+	}
+	if len(failed) != 1 || !contains(failed[0], "max_value") {
+		t.Errorf("Expected max_value failure, got %v", failed)
+	}
+}
+
+func TestEvaluateConstraintsExpired(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{"expires_at": "2026-01-01T00:00:00Z"}}
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	ok, failed, err := p.EvaluateConstraints(map[string]interface{}{"now": now})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok || len(failed) != 1 || !contains(failed[0], "expired") {
+		t.Errorf("Expected expiry failure, got ok=%v failed=%v", ok, failed)
+	}
+}
+
+func TestEvaluateConstraintsUnknownKey(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{"max_value": 10.0, "color": "blue"}}
+	ok, messages, err := p.EvaluateConstraints(map[string]interface{}{"value": 5.0})
+	if err != nil {
+		t.Fatalf("Unknown key should not be fatal: %v", err)
+	}
+	if !ok {
+		t.Error("Unknown key should not fail evaluation")
+	}
+	if len(messages) != 1 || !contains(messages[0], "color") {
+		t.Errorf("Expected unknown key to be reported, got %v", messages)
+	}
+}
+
+func TestEvaluateConstraintsFromJSON(t *testing.T) {
+	var p Policy
+	if err := json.Unmarshal([]byte(`{"capabilities":[],"constraints":{"allowed_networks":["mainnet"]}}`), &p); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	ok, _, err := p.EvaluateConstraints(map[string]interface{}{"network": "local"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("Expected local to be rejected by allowed_networks")
+	}
+}
+
+func TestEvaluateConstraintsMalformed(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{"max_value": "lots"}}
+	if _, _, err := p.EvaluateConstraints(nil); err == nil {
+		t.Error("Expected error for non-numeric max_value")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Conflict Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestPolicyConflictsClean(t *testing.T) {
+	p := Policy{Capabilities: []string{"read:lct", "write:lct", "witness:attest"}}
+	if c := p.Conflicts(); len(c) != 0 {
+		t.Errorf("Expected no conflicts, got %v", c)
+	}
+}
+
+func TestPolicyConflictsDenyAll(t *testing.T) {
+	p := Policy{Capabilities: []string{"deny:all", "write:lct"}}
+	c := p.Conflicts()
+	if len(c) != 1 || !contains(c[0], "deny:all conflicts with write:lct") {
+		t.Errorf("Expected deny:all/write:lct conflict, got %v", c)
+	}
+
+	doc := minimalValidDoc()
+	doc.Policy = p
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Conflicts should warn, not fail: %v", result.Errors)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		if contains(w, "Policy conflict") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected policy conflict warning, got %v", result.Warnings)
+	}
+}
+
+func TestPolicyConflictsRedundantOverlap(t *testing.T) {
+	p := Policy{Capabilities: []string{"write:*", "write:lct", "read:lct"}}
+	c := p.Conflicts()
+	if len(c) != 1 || !contains(c[0], "write:lct is redundant with write:*") {
+		t.Errorf("Expected redundancy report, got %v", c)
+	}
+}
+
+func TestPolicyConflictsCustomTable(t *testing.T) {
+	defer func(t []CapabilityConflict) { CapabilityConflictTable = t }(CapabilityConflictTable)
+	CapabilityConflictTable = append(CapabilityConflictTable, CapabilityConflict{
+		A: "audit:lct", B: "write:lct", Reason: "auditors may not modify what they audit",
+	})
+
+	p := Policy{Capabilities: []string{"audit:lct", "write:lct"}}
+	if c := p.Conflicts(); len(c) != 1 || !contains(c[0], "auditors") {
+		t.Errorf("Expected custom conflict, got %v", c)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Grammar Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestValidateCapability(t *testing.T) {
+	for _, c := range []string{"witness:attest", "read:*", "mcp:filesystem:testnet", "mcp:filesystem:*", "admin:self", "*"} {
+		if err := ValidateCapability(c); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", c, err)
+		}
+	}
+	for _, c := range []string{"write::lct", "write:", "write", "Write:lct", ":lct", "read:lct:", "a:b:c:d", "read:l*", "**"} {
+		if err := ValidateCapability(c); err == nil {
+			t.Errorf("Expected %q to be rejected", c)
+		}
+	}
+}
+
+func TestValidateDocumentGrantAllCapability(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Policy.Capabilities = []string{"*"}
+	result := ValidateDocument(doc)
+	for _, w := range result.Warnings {
+		if contains(w, "Malformed capability") {
+			t.Errorf("Expected grant-all capability to be well-formed, got %q", w)
+		}
+	}
+	if !doc.Policy.Authorizes("write:lct") {
+		t.Error("Expected grant-all capability to authorize any capability")
+	}
+}
+
+func TestValidateDocumentMalformedCapability(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Policy.Capabilities = append(doc.Policy.Capabilities, "write::lct", "write:")
+	result := ValidateDocument(doc)
+	if !result.Valid {
+		t.Fatalf("Malformed capabilities should only warn, got %v", result.Errors)
+	}
+	found := 0
+	for _, w := range result.Warnings {
+		if contains(w, "Malformed capability") {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("Expected 2 malformed capability warnings, got %v", result.Warnings)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Risk Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestRecommendTrustThreshold(t *testing.T) {
+	tests := []struct {
+		name string
+		caps []string
+		want float64
+	}{
+		{"read-only", []string{"read:lct", "read:mrh"}, 0.3},
+		{"write", []string{"read:lct", "write:lct"}, 0.7},
+		{"admin", []string{"write:lct", "admin:society"}, 0.9},
+		{"unknown", []string{"read:lct", "teleport:now"}, DefaultCapabilityThreshold},
+		{"empty", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecommendTrustThreshold(tt.caps); got != tt.want {
+				t.Errorf("RecommendTrustThreshold(%v) = %g, want %g", tt.caps, got, tt.want)
+			}
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Capability Expiry Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestCapabilityExpiry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	doc := minimalValidDoc()
+	doc.Policy.Capabilities = []string{"witness:attest", "write:lct", "read:lct"}
+
+	// Round-trip through JSON so the map arrives as map[string]interface{}
+	raw := `{"capability_expiry":{"witness:attest":"2026-05-01T00:00:00Z","write:lct":"2026-07-01T00:00:00Z"}}`
+	if err := json.Unmarshal([]byte(raw), &doc.Policy.Constraints); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	active := doc.ActiveCapabilities(now)
+	if len(active) != 2 || active[0] != "write:lct" || active[1] != "read:lct" {
+		t.Errorf("Expected write:lct and read:lct active, got %v", active)
+	}
+	expired := doc.ExpiredCapabilities(now)
+	if len(expired) != 1 || expired[0] != "witness:attest" {
+		t.Errorf("Expected witness:attest expired, got %v", expired)
+	}
+
+	if ok, msgs, err := doc.Policy.EvaluateConstraints(nil); !ok || len(msgs) != 0 || err != nil {
+		t.Errorf("capability_expiry should not be evaluated as a runtime constraint, got %v %v %v", ok, msgs, err)
+	}
+}
+
+func TestCapabilityExpiryAbsent(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.Policy.Capabilities = []string{"witness:attest", "write:lct"}
+	if active := doc.ActiveCapabilities(time.Now()); len(active) != 2 {
+		t.Errorf("Expected all capabilities active without an expiry map, got %v", active)
+	}
+	if expired := doc.ExpiredCapabilities(time.Now()); len(expired) != 0 {
+		t.Errorf("Expected no expired capabilities, got %v", expired)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Policy Merging Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestMergePolicies(t *testing.T) {
+	society := Policy{
+		Capabilities: []string{"read:lct", "witness:attest"},
+		Constraints:  map[string]interface{}{ConstraintMaxValue: 1000.0, ConstraintAllowedNetworks: []string{"mainnet", "testnet"}},
+	}
+	role := Policy{
+		Capabilities: []string{"write:lct", "read:lct"},
+		Constraints:  map[string]interface{}{ConstraintMaxValue: 500.0, ConstraintMinTrust: 0.6},
+	}
+	citizen := Policy{
+		Capabilities: []string{"witness:attest", "admin:self"},
+		Constraints:  map[string]interface{}{ConstraintMaxValue: 100.0},
+	}
+
+	merged := MergePolicies(society, role, citizen)
+
+	want := []string{"admin:self", "read:lct", "witness:attest", "write:lct"}
+	if len(merged.Capabilities) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, merged.Capabilities)
+	}
+	for i := range want {
+		assertEqual(t, "capability", want[i], merged.Capabilities[i])
+	}
+
+	if merged.Constraints[ConstraintMaxValue] != 100.0 {
+		t.Errorf("Expected last writer's max_value 100, got %v", merged.Constraints[ConstraintMaxValue])
+	}
+	if merged.Constraints[ConstraintMinTrust] != 0.6 {
+		t.Errorf("Expected min_trust from the role layer, got %v", merged.Constraints[ConstraintMinTrust])
+	}
+	networks := merged.Constraints[ConstraintAllowedNetworks].([]string)
+	networks[0] = "changed"
+	if society.Constraints[ConstraintAllowedNetworks].([]string)[0] != "mainnet" {
+		t.Error("Merged constraints should not alias the inputs")
+	}
+
+	// Incompatible values: the later layer replaces wholesale
+	override := MergePolicies(society, Policy{Constraints: map[string]interface{}{ConstraintMaxValue: "unbounded"}})
+	if override.Constraints[ConstraintMaxValue] != "unbounded" {
+		t.Errorf("Expected later value of a different type to win, got %v", override.Constraints[ConstraintMaxValue])
+	}
+
+	if empty := MergePolicies(); empty.Capabilities == nil || empty.Constraints != nil {
+		t.Errorf("Expected empty capabilities and nil constraints, got %+v", empty)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Implied Capability Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestImpliedCapabilitiesReadScope(t *testing.T) {
+	p := Policy{
+		Capabilities: []string{"witness:attest"},
+		Constraints:  map[string]interface{}{ConstraintReadScope: []interface{}{"mrh", "lct"}},
+	}
+
+	implied := p.ImpliedCapabilities()
+	if len(implied) != 1 || implied[0] != "read:*" {
+		t.Fatalf("Expected read_scope to imply read:*, got %v", implied)
+	}
+	if !p.Authorizes("read:lct") {
+		t.Error("Expected implied read:* to authorize read:lct")
+	}
+	if !p.Authorizes("witness:attest") {
+		t.Error("Expected explicit capability to be authorized")
+	}
+	if p.Authorizes("write:lct") {
+		t.Error("write:lct is neither listed nor implied")
+	}
+
+	if ok, msgs, _ := p.EvaluateConstraints(nil); !ok || len(msgs) != 0 {
+		t.Errorf("Scope constraints should not be evaluated at runtime, got %v", msgs)
+	}
+}
+
+func TestImpliedCapabilitiesEmptyAndDenied(t *testing.T) {
+	p := Policy{Constraints: map[string]interface{}{ConstraintReadScope: "", ConstraintWriteScope: false}}
+	if implied := p.ImpliedCapabilities(); len(implied) != 0 {
+		t.Errorf("Expected empty scopes to imply nothing, got %v", implied)
+	}
+
+	p = Policy{
+		Capabilities: []string{"deny:read"},
+		Constraints:  map[string]interface{}{ConstraintReadScope: "all"},
+	}
+	if p.Authorizes("read:lct") {
+		t.Error("Expected deny:read to override the implied read:*")
+	}
+}
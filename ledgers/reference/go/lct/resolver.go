@@ -0,0 +1,116 @@
+package lct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by resolvers when no document exists for an LCT ID.
+var ErrNotFound = errors.New("lct not found")
+
+// Resolver fetches LCT documents by LCT ID, typically from an external
+// registry. Implementations must honor ctx cancellation.
+type Resolver interface {
+	Resolve(ctx context.Context, lctID string) (*Document, error)
+}
+
+// ResolverFunc adapts an ordinary function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, lctID string) (*Document, error)
+
+// Resolve calls f(ctx, lctID).
+func (f ResolverFunc) Resolve(ctx context.Context, lctID string) (*Document, error) {
+	return f(ctx, lctID)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// MapResolver
+// ═══════════════════════════════════════════════════════════════
+
+// MapResolver resolves documents from an in-memory map keyed by LCT ID.
+type MapResolver map[string]*Document
+
+// NewMapResolver indexes docs by LCT ID.
+func NewMapResolver(docs ...*Document) MapResolver {
+	m := make(MapResolver, len(docs))
+	for _, doc := range docs {
+		m[doc.LCTID] = doc
+	}
+	return m
+}
+
+// Resolve returns the document for lctID or an error wrapping ErrNotFound.
+func (m MapResolver) Resolve(ctx context.Context, lctID string) (*Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	doc, ok := m[lctID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, lctID)
+	}
+	return doc, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// CachingResolver
+// ═══════════════════════════════════════════════════════════════
+
+// CachingResolver wraps a Resolver, caching successful lookups for TTL.
+// Errors are never cached. It is safe for concurrent use.
+type CachingResolver struct {
+	next Resolver
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	doc     *Document
+	expires time.Time
+}
+
+// NewCachingResolver caches lookups from next for ttl.
+func NewCachingResolver(next Resolver, ttl time.Duration) *CachingResolver {
+	return &CachingResolver{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// Resolve returns a cached document if one is fresh, otherwise resolves
+// through the wrapped resolver and caches the result.
+func (c *CachingResolver) Resolve(ctx context.Context, lctID string) (*Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[lctID]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.doc, nil
+	}
+
+	doc, err := c.next.Resolve(ctx, lctID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[lctID] = cacheEntry{doc: doc, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return doc, nil
+}
+
+// Invalidate drops lctID from the cache.
+func (c *CachingResolver) Invalidate(lctID string) {
+	c.mu.Lock()
+	delete(c.entries, lctID)
+	c.mu.Unlock()
+}
@@ -0,0 +1,100 @@
+package lct
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingResolver counts lookups that reach the wrapped resolver.
+type countingResolver struct {
+	Resolver
+	calls int
+}
+
+func (c *countingResolver) Resolve(ctx context.Context, lctID string) (*Document, error) {
+	c.calls++
+	return c.Resolver.Resolve(ctx, lctID)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Resolver Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestMapResolver(t *testing.T) {
+	doc := minimalValidDoc()
+	r := NewMapResolver(doc)
+
+	got, err := r.Resolve(context.Background(), doc.LCTID)
+	if err != nil || got != doc {
+		t.Errorf("Expected document, got %v (%v)", got, err)
+	}
+	if _, err := r.Resolve(context.Background(), "lct:web4:ai:missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCachingResolverHitMiss(t *testing.T) {
+	doc := minimalValidDoc()
+	backend := &countingResolver{Resolver: NewMapResolver(doc)}
+	cache := NewCachingResolver(backend, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Resolve(ctx, doc.LCTID); err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+	}
+	if backend.calls != 1 {
+		t.Errorf("Expected 1 backend call (miss then hits), got %d", backend.calls)
+	}
+
+	// Misses are not cached
+	cache.Resolve(ctx, "lct:web4:ai:missing")
+	cache.Resolve(ctx, "lct:web4:ai:missing")
+	if backend.calls != 3 {
+		t.Errorf("Expected errors to bypass the cache, got %d calls", backend.calls)
+	}
+}
+
+func TestCachingResolverTTLExpiry(t *testing.T) {
+	doc := minimalValidDoc()
+	backend := &countingResolver{Resolver: NewMapResolver(doc)}
+	cache := NewCachingResolver(backend, time.Minute)
+	now := time.Date(2026, 2, 19, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	cache.Resolve(ctx, doc.LCTID)
+	now = now.Add(59 * time.Second)
+	cache.Resolve(ctx, doc.LCTID)
+	if backend.calls != 1 {
+		t.Fatalf("Expected cached lookup within TTL, got %d calls", backend.calls)
+	}
+
+	now = now.Add(2 * time.Second)
+	cache.Resolve(ctx, doc.LCTID)
+	if backend.calls != 2 {
+		t.Errorf("Expected refetch after TTL expiry, got %d calls", backend.calls)
+	}
+}
+
+func TestResolverContextCancellation(t *testing.T) {
+	doc := minimalValidDoc()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewMapResolver(doc).Resolve(ctx, doc.LCTID); !errors.Is(err, context.Canceled) {
+		t.Errorf("MapResolver: expected context.Canceled, got %v", err)
+	}
+
+	backend := &countingResolver{Resolver: NewMapResolver(doc)}
+	cache := NewCachingResolver(backend, time.Minute)
+	if _, err := cache.Resolve(ctx, doc.LCTID); !errors.Is(err, context.Canceled) {
+		t.Errorf("CachingResolver: expected context.Canceled, got %v", err)
+	}
+	if backend.calls != 0 {
+		t.Error("Canceled context should not reach the backend")
+	}
+}
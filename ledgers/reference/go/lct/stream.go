@@ -0,0 +1,109 @@
+package lct
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamResult is the per-line result ValidateStream writes for each
+// document in an NDJSON feed.
+type StreamResult struct {
+	Line     int      `json:"line"`
+	LCTID    string   `json:"lct_id"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ValidateStream reads newline-delimited JSON documents from r, validates
+// each with ValidateDocument, and writes one JSON StreamResult per line to
+// w. Malformed lines are counted as invalid and do not stop the stream;
+// blank lines are skipped. The error is non-nil only for I/O failures.
+func ValidateStream(r io.Reader, w io.Writer) (valid int, invalid int, err error) {
+	br := bufio.NewReader(r)
+	enc := json.NewEncoder(w)
+	for lineNo := 1; ; lineNo++ {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return valid, invalid, readErr
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			res := StreamResult{Line: lineNo}
+			var doc Document
+			if jsonErr := json.Unmarshal(line, &doc); jsonErr != nil {
+				res.Errors = []string{fmt.Sprintf("Invalid JSON: %v", jsonErr)}
+			} else {
+				vr := ValidateDocument(&doc)
+				res.LCTID = doc.LCTID
+				res.Valid = vr.Valid
+				res.Errors = vr.Errors
+				res.Warnings = vr.Warnings
+			}
+			if res.Errors == nil {
+				res.Errors = []string{}
+			}
+			if res.Valid {
+				valid++
+			} else {
+				invalid++
+			}
+			if err := enc.Encode(&res); err != nil {
+				return valid, invalid, err
+			}
+		}
+
+		if readErr == io.EOF {
+			return valid, invalid, nil
+		}
+	}
+}
+
+// LoadDocuments reads every document from r, detecting the format from the
+// leading bytes: a JSON array of documents, a single JSON object, or NDJSON
+// (one object per line, blank lines skipped). Errors name the detected
+// format and, for NDJSON, the offending line.
+func LoadDocuments(r io.Reader) ([]*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no documents: input is empty")
+	}
+
+	switch data[0] {
+	case '[':
+		var docs []*Document
+		if err := json.Unmarshal(data, &docs); err != nil {
+			return nil, fmt.Errorf("parsing JSON array: %w", err)
+		}
+		return docs, nil
+	case '{':
+		first, rest, _ := bytes.Cut(data, []byte("\n"))
+		if len(bytes.TrimSpace(rest)) == 0 || !json.Valid(first) {
+			var doc Document
+			if err := json.Unmarshal(data, &doc); err != nil {
+				return nil, fmt.Errorf("parsing JSON object: %w", err)
+			}
+			return []*Document{&doc}, nil
+		}
+		var docs []*Document
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) == 0 {
+				continue
+			}
+			var doc Document
+			if err := json.Unmarshal(line, &doc); err != nil {
+				return nil, fmt.Errorf("parsing NDJSON line %d: %w", i+1, err)
+			}
+			docs = append(docs, &doc)
+		}
+		return docs, nil
+	}
+	return nil, fmt.Errorf("unrecognized document format: expected JSON object, JSON array, or NDJSON, got leading byte %q", data[0])
+}
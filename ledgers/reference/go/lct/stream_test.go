@@ -0,0 +1,110 @@
+package lct
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Stream Validation Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestValidateStream(t *testing.T) {
+	good, _ := json.Marshal(minimalValidDoc())
+	badDoc := minimalValidDoc()
+	badDoc.LCTID = "bad-id"
+	bad, _ := json.Marshal(badDoc)
+
+	feed := string(good) + "\n{not json\n\n" + string(bad) // no trailing newline
+	var out bytes.Buffer
+	valid, invalid, err := ValidateStream(strings.NewReader(feed), &out)
+	if err != nil {
+		t.Fatalf("ValidateStream failed: %v", err)
+	}
+	if valid != 1 || invalid != 2 {
+		t.Errorf("Expected 1 valid and 2 invalid, got %d and %d", valid, invalid)
+	}
+
+	var results []StreamResult
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		var r StreamResult
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("Output line is not JSON: %q", sc.Text())
+		}
+		results = append(results, r)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 result lines, got %d", len(results))
+	}
+
+	if !results[0].Valid || results[0].LCTID != "lct:web4:ai:test0000deadbeef" {
+		t.Errorf("Line 1 should be valid: %+v", results[0])
+	}
+	if results[1].Valid || results[1].Line != 2 || !contains(results[1].Errors[0], "Invalid JSON") {
+		t.Errorf("Line 2 should be recorded as malformed: %+v", results[1])
+	}
+	if results[2].Valid || results[2].Line != 4 || results[2].LCTID != "bad-id" {
+		t.Errorf("Line 4 should be invalid with its lct_id: %+v", results[2])
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Document Loading Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestLoadDocumentsJSONObject(t *testing.T) {
+	data, _ := json.MarshalIndent(minimalValidDoc(), "", "  ")
+	docs, err := LoadDocuments(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+	if len(docs) != 1 || docs[0].LCTID != "lct:web4:ai:test0000deadbeef" {
+		t.Errorf("Expected one document, got %d", len(docs))
+	}
+}
+
+func TestLoadDocumentsJSONArray(t *testing.T) {
+	second := minimalValidDoc()
+	second.LCTID = "lct:web4:ai:second"
+	data, _ := json.Marshal([]*Document{minimalValidDoc(), second})
+	docs, err := LoadDocuments(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+	if len(docs) != 2 || docs[1].LCTID != "lct:web4:ai:second" {
+		t.Errorf("Expected two documents, got %d", len(docs))
+	}
+}
+
+func TestLoadDocumentsNDJSON(t *testing.T) {
+	line, _ := json.Marshal(minimalValidDoc())
+	feed := string(line) + "\n\n" + string(line) + "\n"
+	docs, err := LoadDocuments(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Errorf("Expected two documents, got %d", len(docs))
+	}
+
+	_, err = LoadDocuments(strings.NewReader(string(line) + "\n{not json\n"))
+	if err == nil || !strings.Contains(err.Error(), "NDJSON line 2") {
+		t.Errorf("Expected NDJSON line error, got %v", err)
+	}
+}
+
+func TestLoadDocumentsUnrecognized(t *testing.T) {
+	for _, input := range []string{"", "lct:web4:ai:x", "\xa1\x66lct_id"} {
+		if _, err := LoadDocuments(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+	_, err := LoadDocuments(strings.NewReader("[{]"))
+	if err == nil || !strings.Contains(err.Error(), "JSON array") {
+		t.Errorf("Expected JSON array error, got %v", err)
+	}
+}
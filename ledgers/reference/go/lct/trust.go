@@ -0,0 +1,508 @@
+package lct
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	// ErrNoT3Tensor is returned when a trust computation needs a T3 tensor
+	// and the document has none.
+	ErrNoT3Tensor = errors.New("document has no t3_tensor")
+	// ErrNoV3Tensor is returned when a value computation needs a V3 tensor
+	// and the document has none.
+	ErrNoV3Tensor = errors.New("document has no v3_tensor")
+	// ErrNoRelationship is returned when relational trust is requested
+	// between entities with no MRH relationship.
+	ErrNoRelationship = errors.New("no MRH relationship from observer to subject")
+	// ErrNoTensors is returned by aggregate computations when no input
+	// document carries a tensor.
+	ErrNoTensors = errors.New("no documents with tensors")
+	// ErrNoWitnesses is returned by witness computations when the document's
+	// MRH has no witnessing entries.
+	ErrNoWitnesses = errors.New("document has no MRH witnesses")
+)
+
+// DefaultWitnessTrust is the trust WeightedWitnessTrust assumes for a
+// witness whose own trust is unknown (the lookup returns ErrNotFound).
+var DefaultWitnessTrust = 0.5
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Thresholds
+// ═══════════════════════════════════════════════════════════════
+
+// T3Composite returns the document's T3 composite score, recomputing it
+// from the root dimensions when the stored value is zero.
+func (doc *Document) T3Composite() (float64, error) {
+	if doc.T3 == nil {
+		return 0, ErrNoT3Tensor
+	}
+	if doc.T3.CompositeScore != 0 {
+		return doc.T3.CompositeScore, nil
+	}
+	return ComputeT3Composite(doc.T3), nil
+}
+
+// MeetsTrustThreshold reports whether the document's T3 composite score is
+// at least threshold (as declared by a URI's trust_threshold parameter).
+func (doc *Document) MeetsTrustThreshold(threshold float64) (bool, error) {
+	if threshold < 0 || threshold > 1 {
+		return false, fmt.Errorf("trust threshold must be 0.0-1.0, got %g", threshold)
+	}
+	composite, err := doc.T3Composite()
+	if err != nil {
+		return false, err
+	}
+	return composite >= threshold, nil
+}
+
+// MeetsTrustThresholdWithVeracity is MeetsTrustThreshold with an additional
+// requirement that the V3 veracity is at least minVeracity.
+func (doc *Document) MeetsTrustThresholdWithVeracity(threshold, minVeracity float64) (bool, error) {
+	if minVeracity < 0 || minVeracity > 1 {
+		return false, fmt.Errorf("minimum veracity must be 0.0-1.0, got %g", minVeracity)
+	}
+	ok, err := doc.MeetsTrustThreshold(threshold)
+	if err != nil {
+		return false, err
+	}
+	if doc.V3 == nil {
+		return false, ErrNoV3Tensor
+	}
+	return ok && doc.V3.Veracity >= minVeracity, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Relative Trust
+// ═══════════════════════════════════════════════════════════════
+
+const (
+	// pairedTrustFactor boosts trust in entities the observer is paired with.
+	pairedTrustFactor = 1.2
+	// witnessingTrustFactor applies to entities the observer witnesses.
+	witnessingTrustFactor = 1.0
+	// relationshipHalfLife is the age at which a relationship's recency
+	// weight has decayed halfway from 1.0 to its floor of 0.5.
+	relationshipHalfLife = 30 * 24 * time.Hour
+)
+
+// RelativeTrust computes the observer's trust in subject: the subject's T3
+// composite scaled by the strongest relationship the observer's MRH holds
+// toward it. Paired relationships weigh 1.2 and witnessing 1.0, each
+// multiplied by a recency weight in [0.5, 1.0] that halves its distance to
+// 0.5 every 30 days. The result is clamped to 0.0-1.0.
+func RelativeTrust(observer, subject *Document) (float64, error) {
+	composite, err := subject.T3Composite()
+	if err != nil {
+		return 0, err
+	}
+
+	now := Now().UTC()
+	factor := -1.0
+	for _, p := range observer.MRH.Paired {
+		if p.LCTID == subject.LCTID {
+			factor = math.Max(factor, pairedTrustFactor*recencyWeight(p.TS, now))
+		}
+	}
+	for _, w := range observer.MRH.Witnessing {
+		if w.LCTID == subject.LCTID {
+			factor = math.Max(factor, witnessingTrustFactor*recencyWeight(w.LastAttestation, now))
+		}
+	}
+	if factor < 0 {
+		return 0, ErrNoRelationship
+	}
+	return clamp01(composite * factor), nil
+}
+
+// WeightedWitnessTrust scores the credibility of the document's witnesses:
+// the mean over MRH witnesses of each witness's own trust multiplied by the
+// recency weight of its last attestation. witnessTrust supplies a witness's
+// trust (0.0-1.0); a witness it reports as ErrNotFound counts as
+// DefaultWitnessTrust, and any other error aborts the computation.
+func WeightedWitnessTrust(doc *Document, witnessTrust func(lctID string) (float64, error)) (float64, error) {
+	if len(doc.MRH.Witnessing) == 0 {
+		return 0, ErrNoWitnesses
+	}
+	now := Now().UTC()
+	total := 0.0
+	for _, w := range doc.MRH.Witnessing {
+		trust, err := witnessTrust(w.LCTID)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			trust = DefaultWitnessTrust
+		case err != nil:
+			return 0, fmt.Errorf("witness %s: %w", w.LCTID, err)
+		}
+		total += clamp01(trust) * recencyWeight(w.LastAttestation, now)
+	}
+	return total / float64(len(doc.MRH.Witnessing)), nil
+}
+
+// recencyWeight maps a relationship timestamp to a weight in [0.5, 1.0].
+// Unparseable timestamps are treated as fully stale.
+func recencyWeight(ts string, now time.Time) float64 {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return 0.5
+	}
+	age := now.Sub(t)
+	if age < 0 {
+		age = 0
+	}
+	return 0.5 + 0.5*math.Exp2(-float64(age)/float64(relationshipHalfLife))
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Society Aggregates
+// ═══════════════════════════════════════════════════════════════
+
+// AggregateSocietyTrust averages the root dimensions of every citizen's T3
+// and V3 tensors. Citizens without a given tensor are skipped for it; the
+// LCT IDs of contributing citizens are recorded as ComputationWitnesses.
+// Returns ErrNoTensors if no citizen has either tensor.
+func AggregateSocietyTrust(citizens []*Document) (T3Tensor, V3Tensor, error) {
+	var t3 T3Tensor
+	var v3 V3Tensor
+	for _, c := range citizens {
+		if c.T3 != nil {
+			t3.Talent += c.T3.Talent
+			t3.Training += c.T3.Training
+			t3.Temperament += c.T3.Temperament
+			t3.ComputationWitnesses = append(t3.ComputationWitnesses, c.LCTID)
+		}
+		if c.V3 != nil {
+			v3.Valuation += c.V3.Valuation
+			v3.Veracity += c.V3.Veracity
+			v3.Validity += c.V3.Validity
+			v3.ComputationWitnesses = append(v3.ComputationWitnesses, c.LCTID)
+		}
+	}
+	if len(t3.ComputationWitnesses) == 0 && len(v3.ComputationWitnesses) == 0 {
+		return T3Tensor{}, V3Tensor{}, ErrNoTensors
+	}
+
+	now := Now().UTC().Format(time.RFC3339)
+	if n := float64(len(t3.ComputationWitnesses)); n > 0 {
+		t3.Talent = roundScore(t3.Talent / n)
+		t3.Training = roundScore(t3.Training / n)
+		t3.Temperament = roundScore(t3.Temperament / n)
+		t3.CompositeScore = ComputeT3Composite(&t3)
+		t3.LastComputed = now
+	}
+	if n := float64(len(v3.ComputationWitnesses)); n > 0 {
+		v3.Valuation = roundScore(v3.Valuation / n)
+		v3.Veracity = roundScore(v3.Veracity / n)
+		v3.Validity = roundScore(v3.Validity / n)
+		v3.CompositeScore = ComputeV3Composite(&v3)
+		v3.LastComputed = now
+	}
+	return t3, v3, nil
+}
+
+// RecomputeComposites recomputes the composite score of every T3 and V3
+// tensor in docs, stamping LastComputed with the current time and recording
+// witness among the tensor's ComputationWitnesses (once). It returns the
+// number of documents modified; documents without tensors are skipped.
+func RecomputeComposites(docs []*Document, witness string) int {
+	now := Now().UTC().Format(time.RFC3339)
+	modified := 0
+	for _, doc := range docs {
+		if doc.T3 == nil && doc.V3 == nil {
+			continue
+		}
+		if doc.T3 != nil {
+			doc.T3.CompositeScore = ComputeT3Composite(doc.T3)
+			doc.T3.LastComputed = now
+			doc.T3.ComputationWitnesses = appendUnique(doc.T3.ComputationWitnesses, witness)
+		}
+		if doc.V3 != nil {
+			doc.V3.CompositeScore = ComputeV3Composite(doc.V3)
+			doc.V3.LastComputed = now
+			doc.V3.ComputationWitnesses = appendUnique(doc.V3.ComputationWitnesses, witness)
+		}
+		modified++
+	}
+	return modified
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	if containsString(list, s) {
+		return list
+	}
+	return append(list, s)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Oracles
+// ═══════════════════════════════════════════════════════════════
+
+// TrustOracle supplies externally observed tensors for an LCT, such as
+// those computed by a reputation service.
+type TrustOracle interface {
+	ObserveT3(lctID string) (T3Tensor, error)
+	ObserveV3(lctID string) (V3Tensor, error)
+}
+
+// OracleWitness is implemented by oracles that hold an LCT of their own.
+// RefreshTrust records WitnessLCTID among the ComputationWitnesses of the
+// tensors it updates.
+type OracleWitness interface {
+	WitnessLCTID() string
+}
+
+// RefreshTrust fetches T3 and V3 observations for the document from o and
+// blends them into its tensors by alpha (see T3Tensor.Blend). A missing
+// tensor is blended from DefaultT3 or DefaultV3. If o implements
+// OracleWitness, its LCT ID is added to each tensor's ComputationWitnesses.
+// Both observations are fetched before anything is changed, so an oracle
+// error or invalid alpha leaves the document untouched.
+func (doc *Document) RefreshTrust(o TrustOracle, alpha float64) error {
+	t3obs, err := o.ObserveT3(doc.LCTID)
+	if err != nil {
+		return fmt.Errorf("observing t3 for %s: %w", doc.LCTID, err)
+	}
+	v3obs, err := o.ObserveV3(doc.LCTID)
+	if err != nil {
+		return fmt.Errorf("observing v3 for %s: %w", doc.LCTID, err)
+	}
+
+	t3 := DefaultT3()
+	if doc.T3 != nil {
+		t3 = cloneT3(*doc.T3)
+	}
+	v3 := DefaultV3()
+	if doc.V3 != nil {
+		v3 = cloneV3(*doc.V3)
+	}
+	if err := t3.Blend(t3obs, alpha); err != nil {
+		return err
+	}
+	if err := v3.Blend(v3obs, alpha); err != nil {
+		return err
+	}
+	if w, ok := o.(OracleWitness); ok {
+		if id := w.WitnessLCTID(); id != "" {
+			t3.ComputationWitnesses = appendUnique(t3.ComputationWitnesses, id)
+			v3.ComputationWitnesses = appendUnique(v3.ComputationWitnesses, id)
+		}
+	}
+	doc.T3, doc.V3 = &t3, &v3
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust History
+// ═══════════════════════════════════════════════════════════════
+
+// TrustSnapshot records the document's tensors as they stood at TS.
+// A tensor the document did not carry is recorded as its zero value.
+type TrustSnapshot struct {
+	TS string   `json:"ts"`
+	T3 T3Tensor `json:"t3_tensor"`
+	V3 V3Tensor `json:"v3_tensor"`
+}
+
+// SnapshotTrust appends a copy of the current T3 and V3 tensors to
+// TrustHistory, stamped with now.
+func (doc *Document) SnapshotTrust(now time.Time) {
+	snap := TrustSnapshot{TS: now.UTC().Format(time.RFC3339)}
+	if doc.T3 != nil {
+		snap.T3 = cloneT3(*doc.T3)
+	}
+	if doc.V3 != nil {
+		snap.V3 = cloneV3(*doc.V3)
+	}
+	doc.TrustHistory = append(doc.TrustHistory, snap)
+}
+
+// TrustAt returns the most recent snapshot taken at or before t.
+// Snapshots with unparseable timestamps are ignored.
+func (doc *Document) TrustAt(t time.Time) (*TrustSnapshot, bool) {
+	var best *TrustSnapshot
+	var bestTS time.Time
+	for i := range doc.TrustHistory {
+		ts, err := time.Parse(time.RFC3339, doc.TrustHistory[i].TS)
+		if err != nil || ts.After(t) {
+			continue
+		}
+		if best == nil || !ts.Before(bestTS) {
+			best, bestTS = &doc.TrustHistory[i], ts
+		}
+	}
+	return best, best != nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Population Ranking
+// ═══════════════════════════════════════════════════════════════
+
+// rankTolerance is the composite difference below which two tensors are
+// ranked as tied.
+const rankTolerance = 1e-9
+
+// PercentileRank returns the percentile (0-100) of target's T3 composite
+// within the population. Composites are recomputed rather than trusted
+// from the tensors. Ties count half, so a population of identical tensors
+// ranks every member at 50. Returns ErrNoTensors for an empty population.
+func PercentileRank(tensors []T3Tensor, target T3Tensor) (float64, error) {
+	scores := make([]float64, len(tensors))
+	for i := range tensors {
+		scores[i] = ComputeT3Composite(&tensors[i])
+	}
+	return percentileRank(scores, ComputeT3Composite(&target))
+}
+
+// PercentileRankV3 is PercentileRank for V3 composites.
+func PercentileRankV3(tensors []V3Tensor, target V3Tensor) (float64, error) {
+	scores := make([]float64, len(tensors))
+	for i := range tensors {
+		scores[i] = ComputeV3Composite(&tensors[i])
+	}
+	return percentileRank(scores, ComputeV3Composite(&target))
+}
+
+func percentileRank(scores []float64, target float64) (float64, error) {
+	if len(scores) == 0 {
+		return 0, ErrNoTensors
+	}
+	var below, tied float64
+	for _, s := range scores {
+		switch {
+		case math.Abs(s-target) <= rankTolerance:
+			tied++
+		case s < target:
+			below++
+		}
+	}
+	return (below + tied/2) / float64(len(scores)) * 100, nil
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Lineage Statistics
+// ═══════════════════════════════════════════════════════════════
+
+// LineageStats counts lineage entries by reason across every document.
+func LineageStats(docs []*Document) map[LineageReason]int {
+	counts := map[LineageReason]int{}
+	for _, doc := range docs {
+		for _, l := range doc.Lineage {
+			counts[l.Reason]++
+		}
+	}
+	return counts
+}
+
+// RotationRate returns the fraction of documents with at least one
+// rotation lineage entry, or 0 for an empty set.
+func RotationRate(docs []*Document) float64 {
+	if len(docs) == 0 {
+		return 0
+	}
+	rotated := 0
+	for _, doc := range docs {
+		for _, l := range doc.Lineage {
+			if l.Reason == LineageRotation {
+				rotated++
+				break
+			}
+		}
+	}
+	return float64(rotated) / float64(len(docs))
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Provenance
+// ═══════════════════════════════════════════════════════════════
+
+const (
+	// Provenance weights: lineage, birth witnesses, MRH witness roles.
+	provenanceLineageWeight = 0.4
+	provenanceWitnessWeight = 0.4
+	provenanceRoleWeight    = 0.2
+
+	// Caps beyond which more evidence adds nothing.
+	provenanceLineageCap = 4
+	provenanceWitnessCap = 3
+	provenanceRoleCap    = 4
+)
+
+// ProvenanceScore rates how well-established the document's origin is, in
+// 0.0-1.0:
+//
+//	0.4 × lineage:   half for a genesis entry, half for chain length (cap 4)
+//	0.4 × witnesses: birth witnesses (cap 3, the spec minimum)
+//	0.2 × roles:     distinct witness roles in the MRH (cap 4)
+func (doc *Document) ProvenanceScore() float64 {
+	lineage := 0.0
+	for _, l := range doc.Lineage {
+		if l.Reason == LineageGenesis {
+			lineage = 0.5
+			break
+		}
+	}
+	lineage += 0.5 * capRatio(len(doc.Lineage), provenanceLineageCap)
+
+	witnesses := capRatio(len(doc.BirthCert.BirthWitnesses), provenanceWitnessCap)
+
+	roles := map[WitnessRole]bool{}
+	for _, w := range doc.MRH.Witnessing {
+		roles[w.Role] = true
+	}
+	roleScore := capRatio(len(roles), provenanceRoleCap)
+
+	return clamp01(provenanceLineageWeight*lineage +
+		provenanceWitnessWeight*witnesses +
+		provenanceRoleWeight*roleScore)
+}
+
+// capRatio returns n/limit, capped at 1.
+func capRatio(n, limit int) float64 {
+	return math.Min(float64(n)/float64(limit), 1)
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Summaries
+// ═══════════════════════════════════════════════════════════════
+
+// TrustSummary is a compact view of an LCT's trust posture for external
+// APIs that do not need the full document.
+type TrustSummary struct {
+	LCTID        string
+	EntityType   EntityType
+	T3Composite  float64
+	V3Composite  float64
+	Active       bool
+	WitnessCount int
+}
+
+// Summary condenses the document into a TrustSummary. Composites are
+// recomputed when stored as zero and missing tensors yield zero. The LCT is
+// active at now unless it carries a revocation that has taken effect; a
+// revocation stamped after now is not yet effective. WitnessCount is the
+// number of witnessing relationships in the MRH.
+func (doc *Document) Summary(now time.Time) TrustSummary {
+	s := TrustSummary{
+		LCTID:        doc.LCTID,
+		EntityType:   doc.Binding.EntityType,
+		Active:       true,
+		WitnessCount: len(doc.MRH.Witnessing),
+	}
+	if doc.T3 != nil {
+		s.T3Composite, _ = doc.T3Composite()
+	}
+	if doc.V3 != nil {
+		s.V3Composite = doc.V3.CompositeScore
+		if s.V3Composite == 0 {
+			s.V3Composite = ComputeV3Composite(doc.V3)
+		}
+	}
+	if r := doc.Revocation; r != nil && r.Status == RevocationRevoked {
+		ts, err := time.Parse(time.RFC3339, r.TS)
+		s.Active = err == nil && ts.After(now)
+	}
+	return s
+}
@@ -0,0 +1,513 @@
+package lct
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Threshold Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestMeetsTrustThresholdPassing(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = &T3Tensor{Talent: 0.9, Training: 0.8, Temperament: 0.8, CompositeScore: 0.84}
+
+	ok, err := doc.MeetsTrustThreshold(0.75)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("Expected composite 0.84 to meet threshold 0.75")
+	}
+}
+
+func TestMeetsTrustThresholdFailing(t *testing.T) {
+	doc := minimalValidDoc() // composite 0.5
+
+	ok, err := doc.MeetsTrustThreshold(0.75)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("Expected composite 0.5 to fail threshold 0.75")
+	}
+}
+
+func TestMeetsTrustThresholdRecomputesZeroComposite(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = &T3Tensor{Talent: 1.0, Training: 1.0, Temperament: 1.0}
+
+	ok, err := doc.MeetsTrustThreshold(0.9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("Expected recomputed composite 1.0 to meet threshold 0.9")
+	}
+}
+
+func TestMeetsTrustThresholdNoTensor(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3 = nil
+
+	if _, err := doc.MeetsTrustThreshold(0.5); !errors.Is(err, ErrNoT3Tensor) {
+		t.Errorf("Expected ErrNoT3Tensor, got %v", err)
+	}
+}
+
+func TestMeetsTrustThresholdWithVeracity(t *testing.T) {
+	doc := minimalValidDoc() // composite 0.5, veracity 0.5
+
+	ok, err := doc.MeetsTrustThresholdWithVeracity(0.5, 0.5)
+	if err != nil || !ok {
+		t.Errorf("Expected pass, got ok=%v err=%v", ok, err)
+	}
+
+	ok, err = doc.MeetsTrustThresholdWithVeracity(0.5, 0.9)
+	if err != nil || ok {
+		t.Errorf("Expected veracity failure, got ok=%v err=%v", ok, err)
+	}
+
+	doc.V3 = nil
+	if _, err := doc.MeetsTrustThresholdWithVeracity(0.5, 0.5); !errors.Is(err, ErrNoV3Tensor) {
+		t.Errorf("Expected ErrNoV3Tensor, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Relative Trust Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestRelativeTrustPairedBoost(t *testing.T) {
+	observer := minimalValidDoc()
+	subject := minimalValidDoc()
+	subject.LCTID = "lct:web4:ai:subject"
+	observer.MRH.Paired = append(observer.MRH.Paired, MRHPaired{
+		LCTID:       subject.LCTID,
+		PairingType: PairingOperational,
+		TS:          time.Now().UTC().Format(time.RFC3339),
+	})
+
+	trust, err := RelativeTrust(observer, subject)
+	if err != nil {
+		t.Fatalf("RelativeTrust failed: %v", err)
+	}
+	if trust <= subject.T3.CompositeScore {
+		t.Errorf("Fresh pairing should boost trust above %.2f, got %.3f", subject.T3.CompositeScore, trust)
+	}
+}
+
+func TestRelativeTrustWitnessing(t *testing.T) {
+	observer := minimalValidDoc()
+	subject := minimalValidDoc()
+	subject.LCTID = "lct:web4:ai:subject"
+	observer.MRH.Witnessing = []MRHWitnessing{{
+		LCTID:           subject.LCTID,
+		Role:            WitnessPeer,
+		LastAttestation: time.Now().UTC().Format(time.RFC3339),
+	}}
+
+	trust, err := RelativeTrust(observer, subject)
+	if err != nil {
+		t.Fatalf("RelativeTrust failed: %v", err)
+	}
+	if math.Abs(trust-subject.T3.CompositeScore) > 0.001 {
+		t.Errorf("Fresh witnessing should preserve composite %.2f, got %.3f", subject.T3.CompositeScore, trust)
+	}
+
+	// A stale relationship decays toward half weight
+	observer.MRH.Witnessing[0].LastAttestation = "2020-01-01T00:00:00Z"
+	stale, _ := RelativeTrust(observer, subject)
+	if stale >= trust || stale < subject.T3.CompositeScore*0.5 {
+		t.Errorf("Stale witnessing should decay to [%.3f, %.3f), got %.3f", subject.T3.CompositeScore*0.5, trust, stale)
+	}
+}
+
+func TestRelativeTrustNoRelationship(t *testing.T) {
+	observer := minimalValidDoc()
+	subject := minimalValidDoc()
+	subject.LCTID = "lct:web4:ai:stranger"
+
+	if _, err := RelativeTrust(observer, subject); !errors.Is(err, ErrNoRelationship) {
+		t.Errorf("Expected ErrNoRelationship, got %v", err)
+	}
+}
+
+func TestWeightedWitnessTrust(t *testing.T) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{
+		{LCTID: "lct:web4:oracle:trusted", Role: WitnessTime, LastAttestation: now},
+		{LCTID: "lct:web4:oracle:dubious", Role: WitnessAudit, LastAttestation: now},
+	}
+	trust := map[string]float64{"lct:web4:oracle:trusted": 0.9, "lct:web4:oracle:dubious": 0.1}
+	lookup := func(id string) (float64, error) {
+		if v, ok := trust[id]; ok {
+			return v, nil
+		}
+		return 0, ErrNotFound
+	}
+
+	score, err := WeightedWitnessTrust(doc, lookup)
+	if err != nil {
+		t.Fatalf("WeightedWitnessTrust failed: %v", err)
+	}
+	if math.Abs(score-0.5) > 0.01 {
+		t.Errorf("Expected mean of 0.9 and 0.1, got %f", score)
+	}
+
+	// Replacing the low-trust witness with a stale high-trust one raises the
+	// score less than a fresh one would.
+	trust["lct:web4:oracle:dubious"] = 0.9
+	doc.MRH.Witnessing[1].LastAttestation = "2025-01-01T00:00:00Z"
+	stale, _ := WeightedWitnessTrust(doc, lookup)
+	if stale <= score || stale >= 0.9 {
+		t.Errorf("Expected stale witness to be discounted, got %f", stale)
+	}
+}
+
+func TestWeightedWitnessTrustDefault(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:unknown", Role: WitnessTime, LastAttestation: time.Now().UTC().Format(time.RFC3339)}}
+	score, err := WeightedWitnessTrust(doc, func(string) (float64, error) { return 0, ErrNotFound })
+	if err != nil || math.Abs(score-DefaultWitnessTrust) > 0.01 {
+		t.Errorf("Expected default witness trust, got %f (err=%v)", score, err)
+	}
+
+	if _, err := WeightedWitnessTrust(minimalValidDoc(), nil); !errors.Is(err, ErrNoWitnesses) {
+		t.Errorf("Expected ErrNoWitnesses, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Society Aggregate Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestAggregateSocietyTrust(t *testing.T) {
+	a := minimalValidDoc()
+	a.LCTID = "lct:web4:ai:a"
+	a.T3 = &T3Tensor{Talent: 0.8, Training: 0.6, Temperament: 0.4}
+	a.V3 = &V3Tensor{Valuation: 1.0, Veracity: 0.9, Validity: 0.7}
+	b := minimalValidDoc()
+	b.LCTID = "lct:web4:ai:b"
+	b.T3 = &T3Tensor{Talent: 0.4, Training: 0.8, Temperament: 0.6}
+	b.V3 = &V3Tensor{Valuation: 0.0, Veracity: 0.5, Validity: 0.5}
+	c := minimalValidDoc()
+	c.LCTID = "lct:web4:ai:no-tensors"
+	c.T3, c.V3 = nil, nil
+
+	t3, v3, err := AggregateSocietyTrust([]*Document{a, c, b})
+	if err != nil {
+		t.Fatalf("AggregateSocietyTrust failed: %v", err)
+	}
+	if math.Abs(t3.Talent-0.6) > 0.001 || math.Abs(t3.Training-0.7) > 0.001 || math.Abs(t3.Temperament-0.5) > 0.001 {
+		t.Errorf("Unexpected T3 averages: %+v", t3)
+	}
+	if math.Abs(t3.CompositeScore-ComputeT3Composite(&t3)) > 0.001 {
+		t.Errorf("T3 composite not computed: %f", t3.CompositeScore)
+	}
+	if math.Abs(v3.Valuation-0.5) > 0.001 || math.Abs(v3.Veracity-0.7) > 0.001 {
+		t.Errorf("Unexpected V3 averages: %+v", v3)
+	}
+	assertEqual(t, "t3 witnesses", "lct:web4:ai:a,lct:web4:ai:b", strings.Join(t3.ComputationWitnesses, ","))
+	assertEqual(t, "v3 witnesses", "lct:web4:ai:a,lct:web4:ai:b", strings.Join(v3.ComputationWitnesses, ","))
+}
+
+func TestAggregateSocietyTrustNoTensors(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3, doc.V3 = nil, nil
+	if _, _, err := AggregateSocietyTrust([]*Document{doc}); !errors.Is(err, ErrNoTensors) {
+		t.Errorf("Expected ErrNoTensors, got %v", err)
+	}
+}
+
+func TestRecomputeComposites(t *testing.T) {
+	a := minimalValidDoc()
+	a.T3.Talent = 1.0
+	a.T3.ComputationWitnesses = []string{"lct:web4:society:genesis"}
+	b := minimalValidDoc()
+	b.T3 = nil
+	b.V3.CompositeScore = 0
+	c := minimalValidDoc()
+	c.T3, c.V3 = nil, nil
+
+	witness := "lct:web4:society:genesis"
+	if n := RecomputeComposites([]*Document{a, b, c}, witness); n != 2 {
+		t.Fatalf("Expected 2 documents modified, got %d", n)
+	}
+	if math.Abs(a.T3.CompositeScore-0.7) > 0.001 {
+		t.Errorf("Expected recomputed T3 composite 0.7, got %f", a.T3.CompositeScore)
+	}
+	if math.Abs(b.V3.CompositeScore-0.35) > 0.001 {
+		t.Errorf("Expected recomputed V3 composite 0.35, got %f", b.V3.CompositeScore)
+	}
+	if a.T3.LastComputed == "" || b.V3.LastComputed == "" {
+		t.Error("Expected LastComputed to be stamped")
+	}
+	assertEqual(t, "a t3 witnesses", witness, strings.Join(a.T3.ComputationWitnesses, ","))
+	assertEqual(t, "a v3 witnesses", witness, strings.Join(a.V3.ComputationWitnesses, ","))
+	assertEqual(t, "b v3 witnesses", witness, strings.Join(b.V3.ComputationWitnesses, ","))
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust Oracle Tests
+// ═══════════════════════════════════════════════════════════════
+
+type stubOracle struct {
+	t3  T3Tensor
+	v3  V3Tensor
+	err error
+}
+
+func (o stubOracle) ObserveT3(string) (T3Tensor, error) { return o.t3, o.err }
+func (o stubOracle) ObserveV3(string) (V3Tensor, error) { return o.v3, o.err }
+func (o stubOracle) WitnessLCTID() string               { return "lct:web4:oracle:reputation" }
+
+func TestRefreshTrust(t *testing.T) {
+	doc := minimalValidDoc()
+	oracle := stubOracle{
+		t3: T3Tensor{Talent: 1.0, Training: 0.9, Temperament: 0.1},
+		v3: V3Tensor{Valuation: 0.8, Veracity: 0.5, Validity: 1.0},
+	}
+	if err := doc.RefreshTrust(oracle, 0.5); err != nil {
+		t.Fatalf("RefreshTrust failed: %v", err)
+	}
+	if abs(doc.T3.Talent-0.75) > 0.001 || abs(doc.T3.Training-0.7) > 0.001 || abs(doc.T3.Temperament-0.3) > 0.001 {
+		t.Errorf("Unexpected blended T3 %+v", doc.T3)
+	}
+	if abs(doc.V3.Valuation-0.4) > 0.001 || abs(doc.V3.Veracity-0.5) > 0.001 || abs(doc.V3.Validity-0.75) > 0.001 {
+		t.Errorf("Unexpected blended V3 %+v", doc.V3)
+	}
+	if abs(doc.V3.CompositeScore-ComputeV3Composite(doc.V3)) > 0.001 {
+		t.Error("Expected V3 composite to be recomputed")
+	}
+	assertEqual(t, "t3 witnesses", "lct:web4:oracle:reputation", strings.Join(doc.T3.ComputationWitnesses, ","))
+	assertEqual(t, "v3 witnesses", "lct:web4:oracle:reputation", strings.Join(doc.V3.ComputationWitnesses, ","))
+}
+
+func TestRefreshTrustOracleError(t *testing.T) {
+	doc := minimalValidDoc()
+	before := *doc.T3
+	down := errors.New("oracle unavailable")
+	err := doc.RefreshTrust(stubOracle{err: down}, 0.5)
+	if !errors.Is(err, down) {
+		t.Fatalf("Expected oracle error to propagate, got %v", err)
+	}
+	if doc.T3.Talent != before.Talent || len(doc.T3.ComputationWitnesses) != 0 {
+		t.Errorf("Expected tensors untouched after oracle error, got %+v", doc.T3)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Trust History Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestTrustHistory(t *testing.T) {
+	doc := minimalValidDoc()
+	first := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(48 * time.Hour)
+
+	doc.SnapshotTrust(first)
+	doc.T3.Talent = 0.9
+	doc.SnapshotTrust(second)
+
+	if len(doc.TrustHistory) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(doc.TrustHistory))
+	}
+	if doc.TrustHistory[0].T3.Talent != 0.5 {
+		t.Errorf("First snapshot should not alias the live tensor, got talent %f", doc.TrustHistory[0].T3.Talent)
+	}
+
+	snap, ok := doc.TrustAt(first.Add(24 * time.Hour))
+	if !ok {
+		t.Fatal("Expected a snapshot between the two")
+	}
+	assertEqual(t, "ts", "2026-03-01T00:00:00Z", snap.TS)
+	if snap.T3.Talent != 0.5 {
+		t.Errorf("Expected talent 0.5, got %f", snap.T3.Talent)
+	}
+
+	if snap, _ := doc.TrustAt(second); snap.T3.Talent != 0.9 {
+		t.Errorf("Expected snapshot at the exact time to be returned, got talent %f", snap.T3.Talent)
+	}
+	if _, ok := doc.TrustAt(first.Add(-time.Second)); ok {
+		t.Error("Expected no snapshot before the first")
+	}
+
+	clone := doc.Clone()
+	clone.TrustHistory[0].T3.Talent = 0
+	if doc.TrustHistory[0].T3.Talent != 0.5 {
+		t.Error("Clone should deep-copy trust history")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Population Ranking Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestPercentileRankMedian(t *testing.T) {
+	var population []T3Tensor
+	for _, v := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		population = append(population, T3Tensor{Talent: v, Training: v, Temperament: v})
+	}
+
+	rank, err := PercentileRank(population, T3Tensor{Talent: 0.5, Training: 0.5, Temperament: 0.5})
+	if err != nil {
+		t.Fatalf("PercentileRank failed: %v", err)
+	}
+	if abs(rank-50) > 1e-9 {
+		t.Errorf("Expected median at 50th percentile, got %f", rank)
+	}
+
+	top, _ := PercentileRank(population, T3Tensor{Talent: 1, Training: 1, Temperament: 1})
+	if top != 100 {
+		t.Errorf("Expected tensor above the population at 100, got %f", top)
+	}
+}
+
+func TestPercentileRankTies(t *testing.T) {
+	same := T3Tensor{Talent: 0.6, Training: 0.6, Temperament: 0.6}
+	rank, err := PercentileRank([]T3Tensor{same, same, same, same}, same)
+	if err != nil {
+		t.Fatalf("PercentileRank failed: %v", err)
+	}
+	if abs(rank-50) > 1e-9 {
+		t.Errorf("Expected ties to rank at 50, got %f", rank)
+	}
+
+	v := V3Tensor{Valuation: 2, Veracity: 0.5, Validity: 0.5}
+	rank, err = PercentileRankV3([]V3Tensor{{Valuation: 0.1}, v, {Valuation: 5, Veracity: 1, Validity: 1}}, v)
+	if err != nil {
+		t.Fatalf("PercentileRankV3 failed: %v", err)
+	}
+	if abs(rank-50) > 1e-9 {
+		t.Errorf("Expected V3 median at 50, got %f", rank)
+	}
+}
+
+func TestPercentileRankEmpty(t *testing.T) {
+	if _, err := PercentileRank(nil, T3Tensor{}); !errors.Is(err, ErrNoTensors) {
+		t.Errorf("Expected ErrNoTensors, got %v", err)
+	}
+	if _, err := PercentileRankV3(nil, V3Tensor{}); !errors.Is(err, ErrNoTensors) {
+		t.Errorf("Expected ErrNoTensors, got %v", err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Lineage Statistics Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestLineageStats(t *testing.T) {
+	withLineage := func(reasons ...LineageReason) *Document {
+		doc := minimalValidDoc()
+		for _, r := range reasons {
+			doc.Lineage = append(doc.Lineage, LineageEntry{Reason: r})
+		}
+		return doc
+	}
+	docs := []*Document{
+		withLineage(LineageGenesis),
+		withLineage(LineageGenesis, LineageRotation, LineageRotation),
+		withLineage(LineageGenesis, LineageUpgrade),
+		withLineage(LineageRotation, LineageUpgrade),
+	}
+
+	stats := LineageStats(docs)
+	want := map[LineageReason]int{LineageGenesis: 3, LineageRotation: 3, LineageUpgrade: 2}
+	if len(stats) != len(want) {
+		t.Errorf("Expected %v, got %v", want, stats)
+	}
+	for reason, n := range want {
+		if stats[reason] != n {
+			t.Errorf("%s: expected %d, got %d", reason, n, stats[reason])
+		}
+	}
+
+	if rate := RotationRate(docs); rate != 0.5 {
+		t.Errorf("Expected rotation rate 0.5, got %f", rate)
+	}
+	if rate := RotationRate(nil); rate != 0 {
+		t.Errorf("Expected rotation rate 0 for an empty set, got %f", rate)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Provenance Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestProvenanceScoreGenesisWithWitnesses(t *testing.T) {
+	established := minimalValidDoc()
+	established.Lineage = []LineageEntry{{Reason: LineageGenesis, TS: "2026-02-19T00:00:00Z"}}
+
+	bare := minimalValidDoc()
+	bare.BirthCert.BirthWitnesses = nil
+
+	if established.ProvenanceScore() <= bare.ProvenanceScore() {
+		t.Errorf("Expected genesis document with 3 witnesses (%f) to outscore witness-less one (%f)",
+			established.ProvenanceScore(), bare.ProvenanceScore())
+	}
+	if bare.ProvenanceScore() != 0 {
+		t.Errorf("Expected zero provenance without lineage or witnesses, got %f", bare.ProvenanceScore())
+	}
+}
+
+func TestProvenanceScoreBounded(t *testing.T) {
+	doc := minimalValidDoc()
+	for i := 0; i < 10; i++ {
+		doc.Lineage = append(doc.Lineage, LineageEntry{Reason: LineageGenesis})
+		doc.BirthCert.BirthWitnesses = append(doc.BirthCert.BirthWitnesses, "lct:web4:witness:extra")
+	}
+	for _, role := range []WitnessRole{WitnessTime, WitnessAudit, WitnessOracle, WitnessPeer, WitnessExistence} {
+		doc.MRH.Witnessing = append(doc.MRH.Witnessing, MRHWitnessing{LCTID: "lct:web4:oracle:" + string(role), Role: role})
+	}
+	if s := doc.ProvenanceScore(); s < 0 || s > 1 || math.Abs(s-1) > 0.001 {
+		t.Errorf("Expected saturated score of 1.0, got %f", s)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+// Summary Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestSummary(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.MRH.Witnessing = []MRHWitnessing{{LCTID: "lct:web4:oracle:time", Role: "time"}}
+	s := doc.Summary(time.Now())
+
+	assertEqual(t, "lct_id", doc.LCTID, s.LCTID)
+	assertEqual(t, "entity_type", string(EntityAI), string(s.EntityType))
+	if math.Abs(s.T3Composite-0.5) > 0.001 {
+		t.Errorf("Expected T3 composite 0.5, got %f", s.T3Composite)
+	}
+	if math.Abs(s.V3Composite-0.35) > 0.001 {
+		t.Errorf("Expected V3 composite 0.35, got %f", s.V3Composite)
+	}
+	if !s.Active {
+		t.Error("Expected active summary")
+	}
+	if s.WitnessCount != 1 {
+		t.Errorf("Expected 1 witness, got %d", s.WitnessCount)
+	}
+}
+
+func TestSummaryRevoked(t *testing.T) {
+	doc := minimalValidDoc()
+	doc.T3.CompositeScore = 0
+	doc.Revocation = &Revocation{Status: RevocationRevoked, TS: "2026-03-01T00:00:00Z"}
+
+	if s := doc.Summary(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)); s.Active {
+		t.Error("Expected inactive summary after revocation")
+	}
+	s := doc.Summary(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if !s.Active {
+		t.Error("Revocation stamped after now should not yet take effect")
+	}
+	if math.Abs(s.T3Composite-0.5) > 0.001 {
+		t.Errorf("Expected recomputed T3 composite 0.5, got %f", s.T3Composite)
+	}
+}
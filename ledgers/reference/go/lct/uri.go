@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -34,8 +35,11 @@ type Identity struct {
 	Component string
 	// Instance within component (e.g., "thinker", "guardian", "filesystem")
 	Instance string
-	// Role or capability (e.g., "expert_42", "coordinator", "reader")
+	// Role or capability (e.g., "expert_42", "coordinator", "reader"),
+	// or AnyRole in policy and discovery URIs
 	Role string
+	// Role is the AnyRole wildcard and matches any concrete role
+	RoleWildcard bool
 	// Network identifier (e.g., "testnet", "mainnet", "local")
 	Network string
 	// Version (defaults to "1.0.0")
@@ -48,10 +52,50 @@ type Identity struct {
 	Capabilities []string
 	// Public key hash or DID from URI fragment (optional)
 	PublicKeyHash string
+	// Short key hint preceding the component, as in "z6Mk@sage:..." (optional)
+	KeyHint string
 	// Raw URI string for reference
 	RawURI string
 }
 
+// KeyRefKind classifies the key reference carried in a URI fragment.
+type KeyRefKind string
+
+const (
+	KeyRefDID           KeyRefKind = "did"
+	KeyRefJWKThumbprint KeyRefKind = "jwk-thumbprint"
+	KeyRefRaw           KeyRefKind = "raw"
+)
+
+// KeyRef is a typed view of a URI fragment (Identity.PublicKeyHash).
+type KeyRef struct {
+	Kind  KeyRefKind
+	Value string
+}
+
+// ParseKeyRef classifies a URI fragment: a "did:" prefix is a DID, a
+// 43-character base64url string is an RFC 7638 JWK thumbprint (SHA-256),
+// and anything else is raw. Value is always the fragment unchanged, so
+// BuildURI re-emits it faithfully.
+func ParseKeyRef(fragment string) KeyRef {
+	switch {
+	case strings.HasPrefix(fragment, "did:"):
+		return KeyRef{Kind: KeyRefDID, Value: fragment}
+	case jwkThumbprintPattern.MatchString(fragment):
+		return KeyRef{Kind: KeyRefJWKThumbprint, Value: fragment}
+	}
+	return KeyRef{Kind: KeyRefRaw, Value: fragment}
+}
+
+// KeyRef returns the typed key reference from the URI fragment, or the
+// zero KeyRef if the URI has no fragment.
+func (id *Identity) KeyRef() KeyRef {
+	if id.PublicKeyHash == "" {
+		return KeyRef{}
+	}
+	return ParseKeyRef(id.PublicKeyHash)
+}
+
 // ParseResult is the result of parsing an LCT URI.
 type ParseResult struct {
 	Success  bool
@@ -68,7 +112,7 @@ type ValidationResult struct {
 
 var (
 	// Authority pattern: component:instance:role@network
-	authorityPattern = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*):([a-zA-Z0-9][a-zA-Z0-9_-]*):([a-zA-Z0-9][a-zA-Z0-9_-]*)@([a-z0-9][a-z0-9-]*)$`)
+	authorityPattern = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*):([a-zA-Z0-9][a-zA-Z0-9_-]*):([a-zA-Z0-9][a-zA-Z0-9_-]*|\*)@([a-z0-9][a-z0-9-]*)$`)
 
 	// Component name validation (lowercase alphanumeric with hyphens)
 	componentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
@@ -78,8 +122,30 @@ var (
 
 	// Network name validation
 	networkPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// Key hint validation (multibase-style alphanumeric)
+	keyHintPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	// RFC 7638 JWK thumbprint: base64url (unpadded) SHA-256 digest
+	jwkThumbprintPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+	// URI template placeholder: {name}
+	placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
 )
 
+// knownQueryParams lists the query parameters ParseURI interprets.
+var knownQueryParams = map[string]bool{
+	"version":         true,
+	"pairing_status":  true,
+	"trust_threshold": true,
+	"capabilities":    true,
+	"cap":             true,
+}
+
+// MinimumVersion is the lowest LCT URI version ValidateURI accepts without
+// a warning.
+var MinimumVersion = "1.0.0"
+
 // validPairingStatuses lists accepted pairing status values.
 var validPairingStatuses = map[string]PairingStatus{
 	"pending":   PairingPending,
@@ -97,6 +163,14 @@ var validPairingStatuses = map[string]PairingStatus{
 //	    fmt.Println(result.Identity.Component) // "sage"
 //	}
 func ParseURI(uri string) ParseResult {
+	// Reject a doubled scheme explicitly rather than as a bad authority
+	if strings.HasPrefix(uri, "lct:lct:") || strings.HasPrefix(uri, "lct://lct://") {
+		return ParseResult{
+			Success: false,
+			Errors:  []string{fmt.Sprintf("Invalid LCT URI scheme: doubled \"lct:\" scheme in %q", truncate(uri, 30))},
+		}
+	}
+
 	// Validate scheme
 	if !strings.HasPrefix(uri, "lct://") {
 		return ParseResult{
@@ -124,6 +198,29 @@ func ParseURI(uri string) ParseResult {
 
 	authority := withoutScheme
 
+	// Split off an optional key hint (keyhint@component:instance:role@network).
+	// The network separator is always the last '@'; any other arrangement is
+	// ambiguous.
+	var keyHint string
+	switch strings.Count(authority, "@") {
+	case 0, 1:
+	case 2:
+		idx := strings.Index(authority, "@")
+		keyHint = authority[:idx]
+		if !keyHintPattern.MatchString(keyHint) {
+			return ParseResult{
+				Success: false,
+				Errors:  []string{fmt.Sprintf("Ambiguous LCT authority: invalid key hint %q before component", keyHint)},
+			}
+		}
+		authority = authority[idx+1:]
+	default:
+		return ParseResult{
+			Success: false,
+			Errors:  []string{fmt.Sprintf("Ambiguous LCT authority: too many '@' separators in %q", authority)},
+		}
+	}
+
 	// Parse authority (component:instance:role@network)
 	matches := authorityPattern.FindStringSubmatch(authority)
 	if matches == nil {
@@ -146,7 +243,7 @@ func ParseURI(uri string) ParseResult {
 	if !namePattern.MatchString(instance) {
 		errors = append(errors, fmt.Sprintf("Invalid instance name: %q - must be alphanumeric with underscores/hyphens", instance))
 	}
-	if !namePattern.MatchString(role) {
+	if role != AnyRole && !namePattern.MatchString(role) {
 		errors = append(errors, fmt.Sprintf("Invalid role name: %q - must be alphanumeric with underscores/hyphens", role))
 	}
 	if !networkPattern.MatchString(network) {
@@ -193,12 +290,17 @@ func ParseURI(uri string) ParseResult {
 			}
 		}
 
+		// Capabilities come comma-joined in "capabilities" and/or as
+		// repeated "cap" parameters; both are merged without duplicates.
+		var raw []string
 		if c := params.Get("capabilities"); c != "" {
-			for _, cap := range strings.Split(c, ",") {
-				cap = strings.TrimSpace(cap)
-				if cap != "" {
-					capabilities = append(capabilities, cap)
-				}
+			raw = strings.Split(c, ",")
+		}
+		raw = append(raw, params["cap"]...)
+		for _, cap := range raw {
+			cap = strings.TrimSpace(cap)
+			if cap != "" && !containsString(capabilities, cap) {
+				capabilities = append(capabilities, cap)
 			}
 		}
 	}
@@ -213,18 +315,180 @@ func ParseURI(uri string) ParseResult {
 			Component:      component,
 			Instance:       instance,
 			Role:           role,
+			RoleWildcard:   role == AnyRole,
 			Network:        network,
 			Version:        version,
 			PairingStatus:  pairingStatus,
 			TrustThreshold: trustThreshold,
 			Capabilities:   capabilities,
 			PublicKeyHash:  fragment,
+			KeyHint:        keyHint,
 			RawURI:         uri,
 		},
 		Errors: nil,
 	}
 }
 
+// ParseOptions tightens ParseURI for deployments with stricter requirements.
+type ParseOptions struct {
+	// RequireFragment rejects URIs without a public key hash / DID fragment.
+	RequireFragment bool
+	// AllowedNetworks restricts the network to this list (empty allows any).
+	AllowedNetworks []string
+	// RejectUnknownParams rejects query parameters ParseURI does not
+	// interpret, catching typos like "trust_treshold".
+	RejectUnknownParams bool
+	// AllowSchemeRelative accepts scheme-relative input such as
+	// "//sage:thinker:expert@testnet", treating it as an lct:// URI.
+	AllowSchemeRelative bool
+	// AllowLegacyVersion accepts the semicolon version suffix older systems
+	// emitted after the network ("lct://sage:thinker:expert@testnet;v1"),
+	// reading ";v<N>" as version N.0.0.
+	AllowLegacyVersion bool
+}
+
+// ParseURIStrict parses an LCT URI like ParseURI, then enforces opts.
+// ParseURI remains the lenient default.
+func ParseURIStrict(uri string, opts ParseOptions) ParseResult {
+	if opts.AllowSchemeRelative && strings.HasPrefix(uri, "//") {
+		uri = "lct:" + uri
+	}
+	if opts.AllowLegacyVersion {
+		rewritten, errors := rewriteLegacyVersion(uri)
+		if len(errors) > 0 {
+			return ParseResult{Success: false, Errors: errors}
+		}
+		uri = rewritten
+	}
+	result := ParseURI(uri)
+	if !result.Success {
+		return result
+	}
+
+	id := result.Identity
+	var errors []string
+	if opts.RequireFragment && id.PublicKeyHash == "" {
+		errors = append(errors, "Missing public key fragment: URI must end with \"#{did-or-key-hash}\"")
+	}
+	if len(opts.AllowedNetworks) > 0 {
+		allowed := false
+		for _, n := range opts.AllowedNetworks {
+			if n == id.Network {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			errors = append(errors, fmt.Sprintf("Network not allowed: %q - must be one of %s", id.Network, strings.Join(opts.AllowedNetworks, "|")))
+		}
+	}
+
+	if opts.RejectUnknownParams {
+		for _, p := range unknownQueryParams(uri) {
+			errors = append(errors, fmt.Sprintf("Unknown query parameter: %q", p))
+		}
+	}
+
+	if len(errors) > 0 {
+		return ParseResult{Success: false, Errors: errors}
+	}
+	return result
+}
+
+// rewriteLegacyVersion moves a legacy ";v<N>" suffix on the authority into
+// the canonical "version" query parameter. URIs without the suffix are
+// returned unchanged; a malformed or conflicting suffix is reported as
+// parse errors.
+func rewriteLegacyVersion(uri string) (string, []string) {
+	end := len(uri)
+	if idx := strings.IndexAny(uri, "?#"); idx >= 0 {
+		end = idx
+	}
+	authority, rest := uri[:end], uri[end:]
+	idx := strings.LastIndex(authority, ";v")
+	if idx < 0 {
+		return uri, nil
+	}
+	n, err := strconv.Atoi(authority[idx+2:])
+	if err != nil || n < 0 {
+		return "", []string{fmt.Sprintf("Invalid legacy version suffix: %q - must be \";v<N>\"", authority[idx:])}
+	}
+
+	var query, fragment string
+	if i := strings.Index(rest, "#"); i >= 0 {
+		rest, fragment = rest[:i], rest[i:]
+	}
+	query = strings.TrimPrefix(rest, "?")
+	if params, err := url.ParseQuery(query); err == nil && params.Has("version") {
+		return "", []string{fmt.Sprintf("Conflicting versions: legacy suffix %q and version query parameter", authority[idx:])}
+	}
+
+	out := authority[:idx] + "?version=" + fmt.Sprintf("%d.0.0", n)
+	if query != "" {
+		out += "&" + query
+	}
+	return out + fragment, nil
+}
+
+// ParseURIWithBase parses an LCT URI whose authority may omit the network
+// (e.g. "lct://sage:thinker:expert"), filling it from baseNetwork. A URI
+// that names a network different from baseNetwork is rejected. ParseURI
+// still requires the network.
+func ParseURIWithBase(uri, baseNetwork string) ParseResult {
+	if !strings.HasPrefix(uri, "lct://") {
+		return ParseURI(uri)
+	}
+	end := len(uri)
+	if idx := strings.IndexAny(uri, "?#"); idx >= 0 {
+		end = idx
+	}
+	authority := uri[len("lct://"):end]
+
+	// The network is whatever follows the last '@', unless that text still
+	// contains ':' (then the '@' belongs to a key hint).
+	at := strings.LastIndex(authority, "@")
+	if at < 0 || strings.Contains(authority[at+1:], ":") {
+		result := ParseURI(uri[:end] + "@" + baseNetwork + uri[end:])
+		if result.Success {
+			result.Identity.RawURI = uri
+		}
+		return result
+	}
+
+	result := ParseURI(uri)
+	if result.Success && result.Identity.Network != baseNetwork {
+		return ParseResult{
+			Success: false,
+			Errors:  []string{fmt.Sprintf("Network conflict: URI specifies %q but base network is %q", result.Identity.Network, baseNetwork)},
+		}
+	}
+	return result
+}
+
+// unknownQueryParams returns the sorted, distinct query parameter names in
+// uri that ParseURI does not interpret.
+func unknownQueryParams(uri string) []string {
+	if idx := strings.Index(uri, "#"); idx >= 0 {
+		uri = uri[:idx]
+	}
+	idx := strings.Index(uri, "?")
+	if idx < 0 {
+		return nil
+	}
+	params, err := url.ParseQuery(uri[idx+1:])
+	if err != nil {
+		return nil
+	}
+	var unknown []string
+	for name := range params {
+		if !knownQueryParams[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
 // ValidateURI validates an LCT URI format without fully parsing it.
 // Returns validation result with errors and warnings.
 func ValidateURI(uri string) ValidationResult {
@@ -244,8 +508,14 @@ func ValidateURI(uri string) ValidationResult {
 		warnings = append(warnings, fmt.Sprintf("Low trust threshold (%.2f) may allow untrusted operations", id.TrustThreshold))
 	}
 
-	if id.Version != "1.0.0" {
-		warnings = append(warnings, fmt.Sprintf("Non-standard version: %s", id.Version))
+	for _, p := range unknownQueryParams(uri) {
+		warnings = append(warnings, fmt.Sprintf("Unknown query parameter ignored: %q", p))
+	}
+
+	if _, _, _, err := ParseVersion(id.Version); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Non-standard version: %s (%v)", id.Version, err))
+	} else if major, minor, patch, err := ParseVersion(MinimumVersion); err == nil && !id.VersionAtLeast(major, minor, patch) {
+		warnings = append(warnings, fmt.Sprintf("Version %s is below minimum %s", id.Version, MinimumVersion))
 	}
 
 	return ValidationResult{Valid: true, Warnings: warnings}
@@ -255,11 +525,19 @@ func ValidateURI(uri string) ValidationResult {
 func BuildURI(id *Identity) string {
 	var b strings.Builder
 	b.WriteString("lct://")
+	if id.KeyHint != "" {
+		b.WriteString(id.KeyHint)
+		b.WriteByte('@')
+	}
 	b.WriteString(id.Component)
 	b.WriteByte(':')
 	b.WriteString(id.Instance)
 	b.WriteByte(':')
-	b.WriteString(id.Role)
+	if id.isRoleWildcard() {
+		b.WriteString(AnyRole)
+	} else {
+		b.WriteString(id.Role)
+	}
 	b.WriteByte('@')
 	b.WriteString(id.Network)
 
@@ -291,10 +569,158 @@ func BuildURI(id *Identity) string {
 	return b.String()
 }
 
+// BuildURIValidated is BuildURI that first checks every field against the
+// rules ParseURI enforces, so the result always parses. The error
+// describes the first invalid field.
+func BuildURIValidated(id *Identity) (string, error) {
+	if id == nil {
+		return "", fmt.Errorf("nil identity")
+	}
+	if err := id.validateFields(); err != nil {
+		return "", err
+	}
+	return BuildURI(id), nil
+}
+
+// validateFields checks identity fields against the ParseURI rules.
+func (id *Identity) validateFields() error {
+	if !componentPattern.MatchString(id.Component) {
+		return fmt.Errorf("invalid component name: %q - must be lowercase alphanumeric with hyphens", id.Component)
+	}
+	if !namePattern.MatchString(id.Instance) {
+		return fmt.Errorf("invalid instance name: %q - must be alphanumeric with underscores/hyphens", id.Instance)
+	}
+	if !id.isRoleWildcard() && !namePattern.MatchString(id.Role) {
+		return fmt.Errorf("invalid role name: %q - must be alphanumeric with underscores/hyphens", id.Role)
+	}
+	if !networkPattern.MatchString(id.Network) {
+		return fmt.Errorf("invalid network name: %q - must be lowercase alphanumeric with hyphens", id.Network)
+	}
+	if id.PairingStatus != "" {
+		if _, ok := validPairingStatuses[string(id.PairingStatus)]; !ok {
+			return fmt.Errorf("invalid pairing_status: %q - must be pending|active|suspended|revoked", id.PairingStatus)
+		}
+	}
+	if id.TrustThreshold > 1 || (id.TrustThreshold < 0 && id.TrustThreshold != -1) {
+		return fmt.Errorf("invalid trust_threshold: %g - must be between 0 and 1, or -1 for unset", id.TrustThreshold)
+	}
+	for _, c := range id.Capabilities {
+		if c == "" || strings.TrimSpace(c) != c || strings.Contains(c, ",") {
+			return fmt.Errorf("invalid capability: %q - must be non-empty without commas or surrounding spaces", c)
+		}
+	}
+	if id.KeyHint != "" && !keyHintPattern.MatchString(id.KeyHint) {
+		return fmt.Errorf("invalid key hint: %q - must be alphanumeric with underscores/hyphens", id.KeyHint)
+	}
+	if strings.Contains(id.PublicKeyHash, "#") {
+		return fmt.Errorf("invalid public key hash: %q - must not contain \"#\"", id.PublicKeyHash)
+	}
+	return nil
+}
+
+// ExpandURITemplate expands {name} placeholders in tmpl with every
+// combination of the substitution lists in vars, returning the cartesian
+// product in placeholder order. Each expanded URI must pass ParseURI.
+//
+// Example:
+//
+//	uris, _ := lct.ExpandURITemplate("lct://sage:thinker:{role}@{net}", map[string][]string{
+//	    "role": {"expert_1", "expert_2"},
+//	    "net":  {"testnet", "mainnet"},
+//	})
+//	// 4 URIs: expert_1@testnet, expert_1@mainnet, expert_2@testnet, expert_2@mainnet
+func ExpandURITemplate(tmpl string, vars map[string][]string) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range placeholderPattern.FindAllStringSubmatch(tmpl, -1) {
+		name := m[1]
+		if seen[name] {
+			continue
+		}
+		if len(vars[name]) == 0 {
+			return nil, fmt.Errorf("unexpanded placeholder {%s}: no substitution values", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	uris := []string{tmpl}
+	for _, name := range names {
+		token := "{" + name + "}"
+		next := make([]string, 0, len(uris)*len(vars[name]))
+		for _, partial := range uris {
+			for _, value := range vars[name] {
+				next = append(next, strings.ReplaceAll(partial, token, value))
+			}
+		}
+		uris = next
+	}
+
+	for _, uri := range uris {
+		if strings.ContainsAny(uri, "{}") {
+			return nil, fmt.Errorf("unexpanded placeholder in %q", uri)
+		}
+		if result := ParseURI(uri); !result.Success {
+			return nil, fmt.Errorf("expanded URI %q is invalid: %s", uri, strings.Join(result.Errors, "; "))
+		}
+	}
+	return uris, nil
+}
+
+// ParseVersion parses a "major.minor.patch" semantic version. Prefixes
+// such as "v" and pre-release or build suffixes are rejected.
+func ParseVersion(s string) (major, minor, patch int, err error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid version %q: expected major.minor.patch", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || p != strconv.Itoa(n) {
+			return 0, 0, 0, fmt.Errorf("invalid version %q: segment %q is not a non-negative integer", s, p)
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], nil
+}
+
+// VersionAtLeast reports whether the identity's version is at least
+// major.minor.patch. Unparseable versions are never at least anything.
+func (id *Identity) VersionAtLeast(major, minor, patch int) bool {
+	ma, mi, pa, err := ParseVersion(id.Version)
+	if err != nil {
+		return false
+	}
+	if ma != major {
+		return ma > major
+	}
+	if mi != minor {
+		return mi > minor
+	}
+	return pa >= patch
+}
+
 // Canonical returns the canonical string representation for an Identity.
-// Format: "component:instance:role@network"
+// Format: "component:instance:role@network", with AnyRole as the role of
+// a wildcard identity.
 func (id *Identity) Canonical() string {
-	return fmt.Sprintf("%s:%s:%s@%s", id.Component, id.Instance, id.Role, id.Network)
+	role := id.Role
+	if id.RoleWildcard {
+		role = AnyRole
+	}
+	return id.Component + ":" + id.Instance + ":" + role + "@" + id.Network
+}
+
+// Key returns Canonical() for use as a map key in hot deduplication loops.
+// It reflects the identity's current fields. The format is stable across
+// releases and safe to persist.
+//
+// Key equality matches Equals exactly, except that Equals also lets a
+// wildcard role match any concrete role; wildcard keys carry the literal
+// "*" role.
+func (id *Identity) Key() string {
+	return id.Canonical()
 }
 
 // EntityID returns the simple entity ID format used by web4-trust-core.
@@ -305,25 +731,76 @@ func (id *Identity) EntityID() string {
 
 // Equals checks if two Identities refer to the same entity.
 // Compares component, instance, role, and network (ignoring metadata).
+// A wildcard role on either side matches any role.
 func (id *Identity) Equals(other *Identity) bool {
 	if id == nil || other == nil {
 		return id == other
 	}
 	return id.Component == other.Component &&
 		id.Instance == other.Instance &&
-		id.Role == other.Role &&
+		(id.MatchesRole(other.Role) || other.isRoleWildcard()) &&
 		id.Network == other.Network
 }
 
-// FromEntityID creates a minimal Identity from a simple "type:name" entity ID.
-func FromEntityID(entityID string, network string, role string) *Identity {
-	if network == "" {
-		network = "local"
+// AnyRole is the role segment that matches every role, as in
+// "lct://sage:thinker:*@testnet". It is valid only as the role.
+const AnyRole = "*"
+
+// MatchesRole reports whether role is the identity's role, or the
+// identity's role is the wildcard, or role is itself AnyRole.
+func (id *Identity) MatchesRole(role string) bool {
+	return id.isRoleWildcard() || role == AnyRole || id.Role == role
+}
+
+func (id *Identity) isRoleWildcard() bool {
+	return id.RoleWildcard || id.Role == AnyRole
+}
+
+// CompareIdentities orders identities by network, then component,
+// instance, and role, returning -1, 0, or 1. Identities with the same
+// four fields compare as 0; a wildcard role sorts before concrete roles.
+// nil sorts before any identity.
+func CompareIdentities(a, b *Identity) int {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
 	}
-	if role == "" {
-		role = "default"
+	if c := strings.Compare(a.Network, b.Network); c != 0 {
+		return c
 	}
-	parts := strings.SplitN(entityID, ":", 2)
+	if c := strings.Compare(a.Component, b.Component); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Instance, b.Instance); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Role, b.Role)
+}
+
+// SortIdentities sorts ids in place by CompareIdentities.
+func SortIdentities(ids []*Identity) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		return CompareIdentities(ids[i], ids[j]) < 0
+	})
+}
+
+// FromEntityID creates a minimal Identity from a trust-core entity ID.
+//
+// Accepted forms:
+//
+//	"type:name"              → network and role from arguments (defaults "local", "default")
+//	"type:name:network"      → embedded network
+//	"type:name:network:role" → embedded network and role
+//
+// Non-empty network and role arguments take precedence over embedded segments.
+func FromEntityID(entityID string, network string, role string) *Identity {
+	parts := strings.SplitN(entityID, ":", 4)
 	component := "unknown"
 	instance := "unknown"
 	if len(parts) >= 1 {
@@ -332,6 +809,18 @@ func FromEntityID(entityID string, network string, role string) *Identity {
 	if len(parts) >= 2 {
 		instance = parts[1]
 	}
+	if network == "" && len(parts) >= 3 {
+		network = parts[2]
+	}
+	if role == "" && len(parts) >= 4 {
+		role = parts[3]
+	}
+	if network == "" {
+		network = "local"
+	}
+	if role == "" {
+		role = "default"
+	}
 	return &Identity{
 		Component: component,
 		Instance:  instance,